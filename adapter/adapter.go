@@ -16,12 +16,14 @@ package adapter
 
 import (
 	"context"
+	"io/fs"
 
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/client-go/dynamic"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
 
 	"github.com/layer5io/gokit/logger"
 	"github.com/mgfeller/common-adapter-library/config"
@@ -32,6 +34,8 @@ type Handler interface {
 	CreateInstance([]byte, string, *chan interface{}) error
 	ApplyOperation(context.Context, OperationRequest) error
 	ListOperations() (Operations, error)
+	ListInstances() ([]string, error)
+	RemoveInstance(string) error
 
 	StreamErr(*Event, error)
 	StreamInfo(*Event)
@@ -42,59 +46,166 @@ type BaseHandler struct {
 	Log     logger.Handler
 	Channel *chan interface{}
 
+	// The clients of the default instance. They are set by CreateInstance and RemoveInstance, and are not safe to read
+	// concurrently with these. Operations use the instance targeted by the request instead, see Instance.
 	KubeClient        *kubernetes.Clientset
 	DynamicKubeClient dynamic.Interface
+	RESTMapper        *restmapper.DeferredDiscoveryRESTMapper
 	KubeConfigPath    string
 	SmiChart          string
+
+	// Templates is the file system operation templates are loaded from, template paths are paths in it,
+	// e.g. an embed.FS, or see TemplatesFromDir and TemplatesFromBundle. If it is nil, templates are loaded from the OS file system.
+	Templates fs.FS
+
+	// WriteKubeconfig enables writing the kubeconfig of an instance to a file, by default it is only kept in memory.
+	// The file is written to a directory named after the instance next to the path in the 'kube-config-path' config key,
+	// KubeConfigPath of the instance is set to the path of the file.
+	WriteKubeconfig bool
+
+	// ApplyMode defines how resources are written to the cluster, defaults to ApplyModeCreate.
+	ApplyMode ApplyMode
+	// FieldManager is the field manager used with ApplyModeServerSide, defaults to DefaultFieldManager.
+	FieldManager string
+
+	// ImpersonateUser applies operations impersonating the user of the request, and its groups, instead of using
+	// the credentials of the adapter, such that cluster RBAC and audit logs apply to the user. Requests without user fail.
+	ImpersonateUser bool
+	// CheckPermissions enables a pre-flight check verifying that all permissions required by an operation are granted,
+	// before any resource is applied.
+	CheckPermissions bool
+	// ValidateManifests enables the validation of rendered manifests against OpenAPI schemas, before any resource is applied.
+	ValidateManifests bool
+	// OpenAPISchemaPath is the path of a local OpenAPI v2 schema bundle in JSON or YAML, e.g. the swagger.json of
+	// a Kubernetes release, used for validation. If it is empty, the schema is retrieved from the cluster.
+	OpenAPISchemaPath string
+	// Locks serializes operations per instance and target namespace if set. Operations applied with ApplyOperation
+	// are locked as a whole, manifests applied otherwise are locked individually.
+	Locks *LockManager
+	// RetryPolicy defines how Kubernetes API calls failing with transient errors are retried, defaults to DefaultRetryPolicy.
+	// Retries are streamed as warning events of the operation.
+	RetryPolicy *RetryPolicy
+
+	instances  instanceRegistry
+	operations operationRegistry
+	schemas    schemaCache
 }
 
 type OperationRequest struct {
-	OperationName     string
-	Namespace         string
-	Username          string
+	OperationName string
+	Namespace     string
+	Username      string
+	// UserGroups are the groups of the user, used when impersonating the user.
+	UserGroups        []string
 	CustomBody        string
 	IsDeleteOperation bool
 	OperationID       string
+	// Instance is the name of the instance the operation is applied to, the default instance if empty.
+	Instance string
+	// DryRun previews the changes of the operation using server-side dry-run, without persisting them.
+	DryRun bool
+	// Parameters are the values given for the parameters of the operation, see Operation.ValidateParameters.
+	// The defaults of parameters without value are applied when the manifest of the operation is rendered.
+	Parameters map[string]string
+	// Result collects the outcome of the operation, e.g. the diff of a dry run. It is optional.
+	Result *OperationResult
+
+	ctx context.Context
+}
+
+// Context returns the context of the request, which cancels the operation when it is done. It defaults to context.Background.
+func (r OperationRequest) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
 }
 
+// WithContext returns a copy of the request with the given context.
+func (r OperationRequest) WithContext(ctx context.Context) OperationRequest {
+	r.ctx = ctx
+	return r
+}
+
+// CreateInstance creates an instance for the cluster of the given kubeconfig context, or of the current context if no context name is given.
+// An existing instance for the same context is replaced. The new instance becomes the default instance, its clients are
+// available in the client fields of the handler.
 func (h *BaseHandler) CreateInstance(kubeconfig []byte, contextName string, ch *chan interface{}) error {
 	h.Channel = ch
-	h.KubeConfigPath = h.Config.GetKey("kube-config-path")
 
-	config, err := h.k8sClientConfig(kubeconfig, contextName)
+	config, name, kubeConfigPath, err := h.k8sClientConfig(kubeconfig, contextName)
 	if err != nil {
 		return ErrClientConfig(err)
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return ErrClientSet(err)
-	}
-
-	h.KubeClient = clientset
-
-	dynamicClient, err := dynamic.NewForConfig(config)
+	instance, err := newInstance(name, config, kubeConfigPath)
 	if err != nil {
 		return err
 	}
-	h.DynamicKubeClient = dynamicClient
-
+	h.instances.add(instance, h.setDefaultInstance)
 	return nil
 }
 
 // creates the namespace unless it is 'default', or it is a delete operation
+//
+// Deprecated: CreateNamespace does not know whether the operation is a dry run, and always creates the namespace.
+// Use CreateOperationNamespace, which previews the creation of the namespace in dry runs.
 func (h *BaseHandler) CreateNamespace(isDelete bool, namespace string) error {
-	if !isDelete && namespace != "default" {
-		if err := h.createNamespace(context.TODO(), namespace); err != nil {
+	return h.CreateOperationNamespace(OperationRequest{IsDeleteOperation: isDelete, Namespace: namespace})
+}
+
+// creates the namespace of the request in the instance targeted by the request, unless it is 'default', or it is a delete operation.
+// In dry runs the creation is previewed, and recorded as a diff in the result of the request.
+func (h *BaseHandler) CreateOperationNamespace(request OperationRequest) error {
+	if !request.IsDeleteOperation && request.Namespace != "default" {
+		instance, err := h.requestInstance(request)
+		if err != nil {
 			logrus.Error(err)
 			return err
 		}
+		created, err := instance.createNamespace(request.Context(), request.Namespace, request.DryRun)
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+		if created && request.DryRun {
+			h.addPreview(request.OperationID, request.Result, ResourceDiff{Action: DiffActionCreated, Kind: "Namespace", Name: request.Namespace})
+		}
 	}
 	return nil
 }
 
+// ApplyKubernetesManifest executes the template with the merge data, and applies the resulting manifest. The parameters
+// of the operation are merged with the merge data: the defaults of the parameters are overridden by the merge data,
+// which is overridden by the parameters of the request.
 func (h *BaseHandler) ApplyKubernetesManifest(request OperationRequest, operation Operation, mergeData map[string]string, templatePath string) error {
-	if err := h.applyK8sManifest(context.TODO(), request, operation, mergeData, templatePath); err != nil {
+	data := operation.parameterDefaults()
+	for key, value := range mergeData {
+		data[key] = value
+	}
+	for key, value := range request.Parameters {
+		data[key] = value
+	}
+	if err := h.applyK8sManifest(request.Context(), request, operation, data, templatePath); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ApplyKubernetesTemplate is like ApplyKubernetesManifest, with structured template data, e.g. nested maps and lists.
+func (h *BaseHandler) ApplyKubernetesTemplate(request OperationRequest, operation Operation, data map[string]interface{}, templatePath string) error {
+	merged := make(map[string]interface{}, len(data))
+	for key, value := range operation.parameterDefaults() {
+		merged[key] = value
+	}
+	for key, value := range data {
+		merged[key] = value
+	}
+	for key, value := range request.Parameters {
+		merged[key] = value
+	}
+	if err := h.applyK8sManifest(request.Context(), request, operation, merged, templatePath); err != nil {
 		logrus.Error(err)
 		return err
 	}
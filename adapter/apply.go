@@ -0,0 +1,138 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ApplyMode defines how the resources of a manifest are written to the cluster.
+type ApplyMode string
+
+const (
+	// ApplyModeCreate creates the resources, resources that already exist are left unchanged. This is the default.
+	ApplyModeCreate ApplyMode = "create"
+	// ApplyModeServerSide applies the resources using server-side apply, resources that already exist are updated in place.
+	ApplyModeServerSide ApplyMode = "server-side"
+
+	// DefaultFieldManager is the field manager used for server-side apply if BaseHandler.FieldManager is not set.
+	DefaultFieldManager = "meshery-adapter"
+)
+
+// applyOptions holds the settings of a single application of a manifest.
+type applyOptions struct {
+	instance   *Instance
+	namespace  string
+	isDelete   bool
+	isCustomOp bool
+	dryRun     bool
+	applyMode  ApplyMode
+
+	operationID string
+	owner       ownership
+	result      *OperationResult
+	resources   *resourceList
+	journal     *journal
+}
+
+// FieldConflict is a field of a resource that is managed by another field manager.
+type FieldConflict struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ConflictError is returned if server-side apply fails because fields of the resource are managed by other field managers.
+type ConflictError struct {
+	Kind      string          `json:"kind,omitempty"`
+	Namespace string          `json:"namespace,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Conflicts []FieldConflict `json:"conflicts,omitempty"`
+}
+
+func (e *ConflictError) Error() string {
+	fields := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		fields = append(fields, fmt.Sprintf("%s (%s)", c.Field, c.Message))
+	}
+	return fmt.Sprintf("conflicts applying resource of type: %s and name: %s/%s: %s", e.Kind, e.Namespace, e.Name, strings.Join(fields, ", "))
+}
+
+// newConflictError extracts the conflicting fields from the status of the API error.
+func newConflictError(data *unstructured.Unstructured, err error) *ConflictError {
+	conflictErr := &ConflictError{
+		Kind:      data.GetKind(),
+		Namespace: data.GetNamespace(),
+		Name:      data.GetName(),
+	}
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				conflictErr.Conflicts = append(conflictErr.Conflicts, FieldConflict{Field: cause.Field, Message: cause.Message})
+			}
+		}
+	}
+	if len(conflictErr.Conflicts) == 0 {
+		conflictErr.Conflicts = append(conflictErr.Conflicts, FieldConflict{Message: err.Error()})
+	}
+	return conflictErr
+}
+
+func (h *BaseHandler) fieldManager() string {
+	if h.FieldManager != "" {
+		return h.FieldManager
+	}
+	return DefaultFieldManager
+}
+
+// applyResource creates or updates the resource using server-side apply.
+func (i *Instance) applyResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured, fieldManager string) error {
+	body, err := data.MarshalJSON()
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to marshal the requested resource")
+		logrus.Error(err)
+		return err
+	}
+	err = retry(ctx, fmt.Sprintf("apply %s %s", data.GetKind(), data.GetName()), func() error {
+		_, err := i.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Patch(ctx, data.GetName(), types.ApplyPatchType, body, metav1.PatchOptions{
+			FieldManager: fieldManager,
+		})
+		if apierrors.IsConflict(err) { // conflicts with other field managers are not transient
+			return newConflictError(data, err)
+		}
+		return err
+	})
+	if err != nil {
+		if conflictErr, ok := err.(*ConflictError); ok {
+			logrus.Error(conflictErr)
+			return conflictErr
+		}
+		err = gherrors.Wrapf(err, "unable to apply the requested resource")
+		logrus.Error(err)
+		return err
+	}
+	logrus.Infof("Applied Resource of type: %s and name: %s", data.GetKind(), data.GetName())
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CancelRegistry tracks in-flight operations by operation ID, so that they can be canceled.
+// The zero value is ready to use. It is safe for concurrent use.
+type CancelRegistry struct {
+	mu      sync.Mutex
+	cancels map[string][]*registeredCancel
+}
+
+// registeredCancel is the cancel function of a single execution of an operation.
+type registeredCancel struct {
+	cancel context.CancelFunc
+}
+
+// WithCancel returns a context of the operation canceled by Cancel, and a function to call when the operation is done.
+// Several executions of an operation with the same operation ID, e.g. a retried request, are all canceled by Cancel,
+// the function only unregisters its own execution. Operations without operation ID cannot be canceled.
+func (r *CancelRegistry) WithCancel(ctx context.Context, operationID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	if operationID == "" {
+		return ctx, cancel
+	}
+
+	registered := &registeredCancel{cancel: cancel}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancels == nil {
+		r.cancels = make(map[string][]*registeredCancel)
+	}
+	r.cancels[operationID] = append(r.cancels[operationID], registered)
+	return ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.remove(operationID, registered)
+		cancel()
+	}
+}
+
+// remove unregisters the execution of the operation, if it is still registered. The caller must hold r.mu.
+func (r *CancelRegistry) remove(operationID string, registered *registeredCancel) {
+	executions := r.cancels[operationID]
+	for i, execution := range executions {
+		if execution == registered {
+			executions = append(executions[:i:i], executions[i+1:]...)
+			break
+		}
+	}
+	if len(executions) == 0 {
+		delete(r.cancels, operationID)
+		return
+	}
+	r.cancels[operationID] = executions
+}
+
+// Cancel cancels all in-flight executions of the operation with the given operation ID, and returns false if there is none.
+func (r *CancelRegistry) Cancel(operationID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	executions, ok := r.cancels[operationID]
+	delete(r.cancels, operationID)
+	for _, execution := range executions {
+		execution.cancel()
+	}
+	return ok
+}
+
+// detachedContext carries the values of its parent context, but neither its deadline nor its cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+// detach returns a context with the values of the given context, which is never canceled.
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
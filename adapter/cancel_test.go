@@ -0,0 +1,72 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCancelRegistry(t *testing.T) {
+	var registry CancelRegistry
+
+	first, firstDone := registry.WithCancel(context.Background(), "op1")
+	defer firstDone()
+	retried, retriedDone := registry.WithCancel(context.Background(), "op1")
+	retriedDone()
+	if retried.Err() == nil {
+		t.Error("expected the context of a finished execution to be canceled")
+	}
+	if first.Err() != nil {
+		t.Fatal("expected a finished execution not to cancel other executions of the operation")
+	}
+
+	if !registry.Cancel("op1") {
+		t.Fatal("expected the operation to be in flight after another execution finished")
+	}
+	if first.Err() != context.Canceled {
+		t.Errorf("expected the operation to be canceled, got %v", first.Err())
+	}
+	if registry.Cancel("op1") {
+		t.Error("expected no operation in flight after cancellation")
+	}
+}
+
+func TestCancelRegistryCancelsAllExecutions(t *testing.T) {
+	var registry CancelRegistry
+	first, firstDone := registry.WithCancel(context.Background(), "op1")
+	defer firstDone()
+	second, secondDone := registry.WithCancel(context.Background(), "op1")
+	defer secondDone()
+
+	if !registry.Cancel("op1") {
+		t.Fatal("expected the operation to be in flight")
+	}
+	if first.Err() == nil || second.Err() == nil {
+		t.Error("expected all executions of the operation to be canceled")
+	}
+}
+
+func TestCancelRegistryWithoutOperationID(t *testing.T) {
+	var registry CancelRegistry
+	ctx, done := registry.WithCancel(context.Background(), "")
+	defer done()
+	if registry.Cancel("") {
+		t.Error("expected operations without operation ID not to be cancelable")
+	}
+	if ctx.Err() != nil {
+		t.Error("expected the operation not to be canceled")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DiffAction is the change of a resource previewed by a dry run.
+type DiffAction string
+
+const (
+	DiffActionCreated DiffAction = "created"
+	DiffActionChanged DiffAction = "changed"
+	DiffActionDeleted DiffAction = "deleted"
+)
+
+// ResourceDiff describes the change of a single resource previewed by a dry run.
+type ResourceDiff struct {
+	Action    DiffAction `json:"action,omitempty"`
+	Kind      string     `json:"kind,omitempty"`
+	Namespace string     `json:"namespace,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	// Diff lists the changed fields, it is only set for changed resources.
+	Diff string `json:"diff,omitempty"`
+}
+
+// OperationResult collects the outcome of an operation. It is safe for concurrent use.
+type OperationResult struct {
+	mu                 sync.Mutex
+	diffs              []ResourceDiff
+	missingPermissions []Permission
+	objects            []ObjectResult
+}
+
+// Diffs returns the changes previewed by a dry run.
+func (r *OperationResult) Diffs() []ResourceDiff {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ResourceDiff{}, r.diffs...)
+}
+
+// MissingPermissions returns the permissions found missing by the pre-flight check.
+func (r *OperationResult) MissingPermissions() []Permission {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Permission{}, r.missingPermissions...)
+}
+
+func (r *OperationResult) addMissingPermissions(permissions []Permission) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.missingPermissions = append(r.missingPermissions, permissions...)
+}
+
+// Objects returns the outcome of every object of the manifests applied by the operation, in the order they have been applied.
+func (r *OperationResult) Objects() []ObjectResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ObjectResult{}, r.objects...)
+}
+
+// addObject records the outcome of the object. A nil result does not record anything.
+func (r *OperationResult) addObject(data *unstructured.Unstructured, outcome ObjectOutcome, err error) {
+	if r == nil {
+		return
+	}
+	object := ObjectResult{
+		Outcome:   outcome,
+		Kind:      data.GetKind(),
+		Namespace: data.GetNamespace(),
+		Name:      data.GetName(),
+	}
+	if err != nil {
+		object.Error = err.Error()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.objects = append(r.objects, object)
+}
+
+func (r *OperationResult) addDiff(diff ResourceDiff) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.diffs = append(r.diffs, diff)
+}
+
+// previewResource determines the change the operation would make to the resource using server-side dry-run,
+// and records it in the operation result. Unchanged resources are not recorded.
+func (h *BaseHandler) previewResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured, opts applyOptions) error {
+	client := opts.instance.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace())
+	dryRun := []string{metav1.DryRunAll}
+
+	existing, err := client.Get(ctx, data.GetName(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		err = gherrors.Wrapf(err, "unable to retrieve the resource for the dry run")
+		logrus.Error(err)
+		return err
+	}
+	exists := err == nil
+
+	diff := ResourceDiff{
+		Kind:      data.GetKind(),
+		Namespace: data.GetNamespace(),
+		Name:      data.GetName(),
+	}
+	switch {
+	case opts.isDelete:
+		if !exists {
+			return nil
+		}
+		if err := client.Delete(ctx, data.GetName(), metav1.DeleteOptions{DryRun: dryRun}); err != nil {
+			err = gherrors.Wrapf(err, "unable to delete the requested resource (dry run)")
+			logrus.Error(err)
+			return err
+		}
+		diff.Action = DiffActionDeleted
+	case !exists:
+		if _, err := client.Create(ctx, data, metav1.CreateOptions{DryRun: dryRun}); err != nil {
+			if !namespaceMissing(err, data.GetNamespace()) {
+				err = gherrors.Wrapf(err, "unable to create the requested resource (dry run)")
+				logrus.Error(err)
+				return err
+			}
+			// the namespace is created by the operation, which is only previewed as well
+			logrus.Debugf("unable to preview the creation of the resource, namespace %s does not exist", data.GetNamespace())
+		}
+		diff.Action = DiffActionCreated
+	case opts.applyMode == ApplyModeServerSide:
+		body, err := data.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		applied, err := client.Patch(ctx, data.GetName(), types.ApplyPatchType, body, metav1.PatchOptions{
+			DryRun:       dryRun,
+			FieldManager: h.fieldManager(),
+		})
+		if err != nil {
+			if apierrors.IsConflict(err) {
+				return newConflictError(data, err)
+			}
+			err = gherrors.Wrapf(err, "unable to apply the requested resource (dry run)")
+			logrus.Error(err)
+			return err
+		}
+		diff.Diff = cmp.Diff(comparableContent(existing), comparableContent(applied))
+		if diff.Diff == "" {
+			return nil
+		}
+		diff.Action = DiffActionChanged
+	case opts.isCustomOp:
+		// custom operations delete and recreate existing resources, the replacement is previewed with an update
+		// in order to include the defaults set by the API server. Updates of immutable fields are rejected though.
+		replacement := data.DeepCopy()
+		replacement.SetResourceVersion(existing.GetResourceVersion())
+		updated, err := client.Update(ctx, replacement, metav1.UpdateOptions{DryRun: dryRun})
+		if err != nil {
+			logrus.Debugf("unable to preview the replacement of the resource with an update: %v", err)
+			updated = data
+		}
+		diff.Diff = cmp.Diff(comparableContent(existing), comparableContent(updated))
+		diff.Action = DiffActionChanged
+	default:
+		// existing resources are left unchanged when they are created
+		return nil
+	}
+
+	h.addPreview(opts.operationID, opts.result, diff)
+	return nil
+}
+
+// addPreview records the previewed change in the result, if any, and streams it as an event of the operation.
+func (h *BaseHandler) addPreview(operationID string, result *OperationResult, diff ResourceDiff) {
+	if result != nil {
+		result.addDiff(diff)
+	}
+	h.streamInfo(operationID, fmt.Sprintf("Dry run: %s %s %s", diff.Kind, diff.Name, diff.Action), diff.Diff)
+}
+
+// namespaceMissing returns true if the error has been caused by the given namespace not existing.
+func namespaceMissing(err error, namespace string) bool {
+	status, ok := gherrors.Cause(err).(apierrors.APIStatus)
+	if !ok || namespace == "" || !apierrors.IsNotFound(gherrors.Cause(err)) {
+		return false
+	}
+	details := status.Status().Details
+	return details != nil && details.Kind == "namespaces" && details.Name == namespace
+}
+
+// comparableContent returns the content of the resource without the fields maintained by the API server.
+func comparableContent(data *unstructured.Unstructured) map[string]interface{} {
+	content := data.DeepCopy().UnstructuredContent()
+	delete(content, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "creationTimestamp", "uid", "selfLink"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	return content
+}
@@ -21,7 +21,8 @@ import (
 )
 
 var (
-	ErrOpInvalid = errors.New(errors.ErrOpInvalid, "Invalid operation")
+	ErrOpInvalid    = errors.New(errors.ErrOpInvalid, "Invalid operation")
+	ErrJobQueueFull = errors.New("1021", "Error submitting operation: the job queue is full")
 )
 
 func ErrInstallMesh(err error) error {
@@ -47,3 +48,53 @@ func ErrClientSet(err error) error {
 func ErrStreamEvent(err error) error {
 	return errors.New(errors.ErrStreamEvent, fmt.Sprintf("Error streaming event: %s", err.Error()))
 }
+
+func ErrRollback(err error) error {
+	return errors.New("1013", fmt.Sprintf("Error rolling back operation: %s", err.Error()))
+}
+
+func ErrInstanceNotFound(name string) error {
+	if name == "" {
+		return errors.New("1014", "Error finding instance: no instance has been created")
+	}
+	return errors.New("1014", fmt.Sprintf("Error finding instance: %s does not exist", name))
+}
+
+func ErrImpersonation(err error) error {
+	return errors.New("1015", fmt.Sprintf("Error impersonating user: %s", err.Error()))
+}
+
+func ErrMissingPermissions(err error) error {
+	return errors.New("1016", fmt.Sprintf("Error checking permissions: %s", err.Error()))
+}
+
+func ErrValidateManifest(err error) error {
+	return errors.New("1017", fmt.Sprintf("Error validating manifest: %s", err.Error()))
+}
+
+func ErrInvalidParameters(err error) error {
+	return errors.New("1018", fmt.Sprintf("Error validating parameters: %s", err.Error()))
+}
+
+func ErrCreateNamespace(err error) error {
+	return errors.New("1019", fmt.Sprintf("Error creating namespace: %s", err.Error()))
+}
+
+func ErrApplyOperation(name string, err error) error {
+	return errors.New("1020", fmt.Sprintf("Error applying operation %s: %s", name, err.Error()))
+}
+
+func ErrJobNotFound(operationID string) error {
+	return errors.New("1023", fmt.Sprintf("Error finding operation: %s does not exist", operationID))
+}
+
+func ErrOperationInProgress(namespace, holder string) error {
+	if holder == "" {
+		return errors.New("1024", fmt.Sprintf("Error locking namespace %s: operation in progress", namespace))
+	}
+	return errors.New("1024", fmt.Sprintf("Error locking namespace %s: operation in progress, held by %s", namespace, holder))
+}
+
+func ErrOperationIDConflict(operationID string) error {
+	return errors.New("1025", fmt.Sprintf("Error applying operation: operation ID %s is used by a different request", operationID))
+}
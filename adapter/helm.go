@@ -0,0 +1,184 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+
+	"k8s.io/client-go/discovery"
+)
+
+// ApplyHelmChart renders the Helm chart archive or directory at chartPath, or at SmiChart if chartPath is empty,
+// and applies the rendered manifests like ApplyKubernetesManifest. Chart values are taken from the operation properties
+// prefixed with HelmValuesPrefix, the release name from HelmReleaseKey.
+//
+// Installing and upgrading a chart both apply its manifests, uninstalling it is a delete operation. Upgrades, see HelmUpgradeKey,
+// are applied with server-side apply. Like Helm, CRDs in the crds/ directory of the chart are installed, but neither upgraded
+// nor deleted. Hooks, including tests, are not supported, they are skipped with a warning event.
+func (h *BaseHandler) ApplyHelmChart(request OperationRequest, operation Operation, chartPath string) error {
+	if chartPath == "" {
+		chartPath = h.SmiChart
+	}
+	upgrade, err := operation.helmUpgrade()
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to apply helm chart (helmUpgrade)")
+		logrus.Error(err)
+		return err
+	}
+	upgrade = upgrade && !request.IsDeleteOperation
+	mode := h.ApplyMode
+	if upgrade {
+		mode = ApplyModeServerSide
+	}
+	err = h.applyManifest(request.Context(), request, operation, mode, func(instance *Instance) (string, error) {
+		manifest, err := h.renderChart(instance, request, operation, chartPath, upgrade)
+		if err != nil {
+			return "", gherrors.Wrapf(err, "unable to apply helm chart (renderChart)")
+		}
+		return manifest, nil
+	})
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// renderChart renders the templates of the chart, and of its enabled dependencies, to a single manifest. Hooks are left out.
+func (h *BaseHandler) renderChart(instance *Instance, request OperationRequest, operation Operation, chartPath string, upgrade bool) (string, error) {
+	if chartPath == "" {
+		return "", errors.New("no helm chart given")
+	}
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return "", gherrors.Wrapf(err, "unable to load helm chart %s", chartPath)
+	}
+	if chrt.Metadata.Type != "" && chrt.Metadata.Type != "application" {
+		return "", fmt.Errorf("helm chart %s of type %s is not installable", chrt.Name(), chrt.Metadata.Type)
+	}
+
+	values, err := operation.helmValues()
+	if err != nil {
+		return "", gherrors.Wrapf(err, "unable to parse helm values")
+	}
+	if err := chartutil.ProcessDependencies(chrt, values); err != nil {
+		return "", gherrors.Wrapf(err, "unable to process dependencies of helm chart %s", chrt.Name())
+	}
+
+	caps, err := instance.capabilities()
+	if err != nil {
+		return "", err
+	}
+	release := operation.Properties[HelmReleaseKey]
+	if release == "" {
+		release = chrt.Name()
+	}
+	options := chartutil.ReleaseOptions{
+		Name:      release,
+		Namespace: request.Namespace,
+		Revision:  1,
+		IsInstall: !request.IsDeleteOperation && !upgrade,
+		IsUpgrade: upgrade,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, values, options, caps)
+	if err != nil {
+		return "", gherrors.Wrapf(err, "unable to compose values of helm chart %s", chrt.Name())
+	}
+	rendered, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return "", gherrors.Wrapf(err, "unable to render helm chart %s", chrt.Name())
+	}
+
+	for name := range rendered {
+		if strings.HasSuffix(name, "NOTES.txt") {
+			delete(rendered, name)
+		}
+	}
+	hooks, manifests, err := releaseutil.SortManifests(rendered, caps.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return "", gherrors.Wrapf(err, "unable to parse the rendered manifests of helm chart %s", chrt.Name())
+	}
+	if len(hooks) > 0 {
+		skipped := make([]string, 0, len(hooks))
+		for _, hook := range hooks {
+			skipped = append(skipped, fmt.Sprintf("%s %s (%s)", hook.Kind, hook.Name, hook.Path))
+		}
+		logrus.Warnf("skipping hooks of helm chart %s: %s", chrt.Name(), strings.Join(skipped, ", "))
+		h.streamWarn(request.OperationID, fmt.Sprintf("Skipped %d hooks of helm chart %s", len(hooks), chrt.Name()), strings.Join(skipped, "\n"))
+	}
+
+	documents := make([]string, 0, len(manifests))
+	if !request.IsDeleteOperation && !upgrade {
+		documents = append(documents, chartCRDs(chrt)...)
+	}
+	for _, manifest := range manifests {
+		documents = append(documents, manifest.Content)
+	}
+	return strings.Join(documents, "\n---\n"), nil
+}
+
+// chartCRDs returns the CRDs in the crds/ directories of the chart and its dependencies.
+func chartCRDs(chrt *chart.Chart) []string {
+	crds := make([]string, 0)
+	for _, crd := range chrt.CRDObjects() {
+		crds = append(crds, string(crd.File.Data))
+	}
+	return crds
+}
+
+// capabilities returns the capabilities of the cluster exposed to chart templates.
+func (i *Instance) capabilities() (*chartutil.Capabilities, error) {
+	version, err := i.discoveryClient.ServerVersion()
+	if err != nil {
+		return nil, gherrors.Wrapf(err, "unable to get the kubernetes version")
+	}
+	groups, resources, err := i.discoveryClient.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, gherrors.Wrapf(err, "unable to get the api versions")
+	}
+
+	versions := chartutil.VersionSet{}
+	for _, group := range groups {
+		for _, groupVersion := range group.Versions {
+			versions = append(versions, groupVersion.GroupVersion)
+		}
+	}
+	for _, list := range resources {
+		for _, resource := range list.APIResources {
+			versions = append(versions, fmt.Sprintf("%s/%s", list.GroupVersion, resource.Kind))
+		}
+	}
+
+	return &chartutil.Capabilities{
+		KubeVersion: chartutil.KubeVersion{
+			Version: version.GitVersion,
+			Major:   version.Major,
+			Minor:   version.Minor,
+		},
+		APIVersions: versions,
+		HelmVersion: chartutil.DefaultCapabilities.HelmVersion,
+	}, nil
+}
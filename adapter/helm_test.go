@@ -0,0 +1,78 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeChart(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Chart.yaml":               "apiVersion: v2\nname: demo\nversion: 0.1.0\n",
+		"crds/widgets.yaml":        "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: widgets.example.com\n",
+		"templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}-config\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRenderChartCRDs(t *testing.T) {
+	chartPath := writeChart(t)
+	instance := fakeInstance(&metav1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+	})
+	h := &BaseHandler{}
+	tests := []struct {
+		name     string
+		isDelete bool
+		upgrade  bool
+		wantCRDs bool
+	}{
+		{name: "install", wantCRDs: true},
+		{name: "upgrade", upgrade: true},
+		{name: "uninstall", isDelete: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := OperationRequest{Namespace: "default", IsDeleteOperation: tt.isDelete}
+			manifest, err := h.renderChart(instance, request, Operation{}, chartPath, tt.upgrade)
+			if err != nil {
+				t.Fatalf("renderChart() error = %v", err)
+			}
+			if !strings.Contains(manifest, "name: demo-config") {
+				t.Errorf("renderChart() = %q, want the rendered templates", manifest)
+			}
+			if got := strings.Contains(manifest, "widgets.example.com"); got != tt.wantCRDs {
+				t.Errorf("renderChart() contains the CRDs = %v, want %v", got, tt.wantCRDs)
+			}
+		})
+	}
+}
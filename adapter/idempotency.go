@@ -0,0 +1,151 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOperationRetention is the default time the outcome of an operation is remembered by an OperationCache.
+const DefaultOperationRetention = time.Hour
+
+// cachedOperation is an operation in progress or applied, done is closed when it has finished.
+type cachedOperation struct {
+	// fingerprint is the fingerprint of the request of the operation, see fingerprint.
+	fingerprint string
+	done        chan struct{}
+	result      *OperationResult
+	err         error
+	finished    time.Time
+}
+
+// OperationCache makes applying operations idempotent: it remembers the outcome of operations by their operation ID,
+// such that a retried operation is not applied again. It is safe for concurrent use.
+type OperationCache struct {
+	retention time.Duration
+	cancels   CancelRegistry
+
+	mu         sync.Mutex
+	operations map[string]*cachedOperation
+}
+
+// NewOperationCache creates a cache remembering the outcome of operations for the retention time,
+// or for DefaultOperationRetention if it is not positive.
+func NewOperationCache(retention time.Duration) *OperationCache {
+	if retention <= 0 {
+		retention = DefaultOperationRetention
+	}
+	return &OperationCache{
+		retention:  retention,
+		operations: make(map[string]*cachedOperation),
+	}
+}
+
+// Apply applies the operation, unless an operation with the same operation ID has been applied before, in which case its
+// outcome is returned, or is in progress, in which case its outcome is awaited. Operations without operation ID are always applied.
+// The returned result is the result of the operation that has actually been applied.
+//
+// Operations with operation ID are applied with a context detached from the context of the caller, such that they are
+// completed when the caller gives up waiting, e.g. when a client retries after a timeout. They are canceled by Cancel only.
+// The outcome of canceled operations is not remembered.
+//
+// ErrOperationIDConflict is returned if the operation ID has been used by a request for a different operation,
+// e.g. with a different operation name, namespace or parameters.
+func (c *OperationCache) Apply(ctx context.Context, request OperationRequest, apply ApplyFunc) (*OperationResult, error) {
+	if request.Result == nil {
+		request.Result = &OperationResult{}
+	}
+	request = request.WithContext(ctx)
+	if request.OperationID == "" {
+		return request.Result, apply(ctx, request)
+	}
+
+	c.mu.Lock()
+	c.expire()
+	operation, ok := c.operations[request.OperationID]
+	if ok && operation.fingerprint != fingerprint(request) {
+		c.mu.Unlock()
+		return request.Result, ErrOperationIDConflict(request.OperationID)
+	}
+	if !ok {
+		operation = &cachedOperation{
+			fingerprint: fingerprint(request),
+			done:        make(chan struct{}),
+			result:      request.Result,
+		}
+		c.operations[request.OperationID] = operation
+		runCtx, done := c.cancels.WithCancel(detach(ctx), request.OperationID)
+		go c.run(runCtx, done, request, apply, operation)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-operation.done:
+		return operation.result, operation.err
+	case <-ctx.Done():
+		return operation.result, ctx.Err()
+	}
+}
+
+// Cancel cancels the operation in progress with the given operation ID, and returns false if there is none.
+func (c *OperationCache) Cancel(operationID string) bool {
+	return c.cancels.Cancel(operationID)
+}
+
+// run applies the operation, and records its outcome. The outcome of a canceled operation is forgotten,
+// such that the operation is applied again when it is retried.
+func (c *OperationCache) run(ctx context.Context, done func(), request OperationRequest, apply ApplyFunc, operation *cachedOperation) {
+	err := apply(ctx, request.WithContext(ctx))
+	canceled := ctx.Err() != nil
+	done()
+
+	c.mu.Lock()
+	operation.err = err
+	operation.finished = time.Now()
+	if canceled && c.operations[request.OperationID] == operation {
+		delete(c.operations, request.OperationID)
+	}
+	c.mu.Unlock()
+	close(operation.done)
+}
+
+// expire removes operations that have finished before the retention time. The caller must hold c.mu.
+func (c *OperationCache) expire() {
+	for id, operation := range c.operations {
+		if !operation.finished.IsZero() && time.Since(operation.finished) > c.retention {
+			delete(c.operations, id)
+		}
+	}
+}
+
+// fingerprint returns the fingerprint of the operation applied by the request. Retries of the request have the same fingerprint.
+func fingerprint(request OperationRequest) string {
+	names := make([]string, 0, len(request.Parameters))
+	for name := range request.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parameters := make([]string, 0, len(names))
+	for _, name := range names {
+		parameters = append(parameters, fmt.Sprintf("%q=%q", name, request.Parameters[name]))
+	}
+	return fmt.Sprintf("%q %q %q %t %t %q %q %q [%s]", request.OperationName, request.Namespace, request.Instance,
+		request.IsDeleteOperation, request.DryRun, request.CustomBody, request.Username, request.UserGroups, strings.Join(parameters, " "))
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOperationCacheAppliesOnce(t *testing.T) {
+	cache := NewOperationCache(0)
+	var calls int32
+	apply := func(ctx context.Context, request OperationRequest) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("failed")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := cache.Apply(context.Background(), OperationRequest{OperationID: "op1"}, apply); err == nil || err.Error() != "failed" {
+			t.Fatalf("expected the error of the operation, got %v", err)
+		}
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected the operation to be applied once, got %d", calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _ = cache.Apply(context.Background(), OperationRequest{}, apply)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 3 {
+		t.Errorf("expected operations without operation ID to be applied every time, got %d", calls)
+	}
+}
+
+func TestOperationCacheDetachesFromCaller(t *testing.T) {
+	cache := NewOperationCache(0)
+	release := make(chan struct{})
+	var calls int32
+	apply := func(ctx context.Context, request OperationRequest) error {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := cache.Apply(ctx, OperationRequest{OperationID: "op1"}, apply); err != context.DeadlineExceeded {
+		t.Fatalf("expected the caller to stop waiting at its deadline, got %v", err)
+	}
+
+	close(release)
+	if _, err := cache.Apply(context.Background(), OperationRequest{OperationID: "op1"}, apply); err != nil {
+		t.Fatalf("expected the retry to get the outcome of the operation, got %v", err)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected the operation to be applied once, got %d", calls)
+	}
+}
+
+func TestOperationCacheCancel(t *testing.T) {
+	cache := NewOperationCache(0)
+	started := make(chan struct{}, 1)
+	var calls int32
+	apply := func(ctx context.Context, request OperationRequest) error {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			return nil
+		}
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	errs := make(chan error)
+	go func() {
+		_, err := cache.Apply(context.Background(), OperationRequest{OperationID: "op1"}, apply)
+		errs <- err
+	}()
+	<-started
+	if !cache.Cancel("op1") {
+		t.Fatal("expected the operation in progress to be canceled")
+	}
+	if err := <-errs; err != context.Canceled {
+		t.Fatalf("expected the operation to be canceled, got %v", err)
+	}
+	if cache.Cancel("op1") {
+		t.Error("expected no operation in progress after cancellation")
+	}
+
+	if _, err := cache.Apply(context.Background(), OperationRequest{OperationID: "op1"}, apply); err != nil {
+		t.Fatalf("expected the canceled operation to be applied again, got %v", err)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 2 {
+		t.Errorf("expected the operation to be applied twice, got %d", calls)
+	}
+}
+
+func TestOperationCacheRejectsDifferentRequests(t *testing.T) {
+	cache := NewOperationCache(0)
+	var calls int32
+	apply := func(ctx context.Context, request OperationRequest) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	request := OperationRequest{OperationID: "op1", OperationName: "install", Namespace: "istio-system", Parameters: map[string]string{"version": "1.7.3"}}
+	if _, err := cache.Apply(context.Background(), request, apply); err != nil {
+		t.Fatal(err)
+	}
+
+	retry := request
+	retry.Parameters = map[string]string{"version": "1.7.3"}
+	if _, err := cache.Apply(context.Background(), retry, apply); err != nil {
+		t.Errorf("expected the retry to get the outcome of the operation, got %v", err)
+	}
+
+	changes := map[string]func(*OperationRequest){
+		"operation name": func(r *OperationRequest) { r.OperationName = "uninstall" },
+		"namespace":      func(r *OperationRequest) { r.Namespace = "default" },
+		"delete":         func(r *OperationRequest) { r.IsDeleteOperation = true },
+		"parameters":     func(r *OperationRequest) { r.Parameters = map[string]string{"version": "1.8.0"} },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			different := request
+			change(&different)
+			if _, err := cache.Apply(context.Background(), different, apply); err == nil {
+				t.Error("expected the request with a different operation for the same operation ID to be rejected")
+			}
+		})
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("expected the operation to be applied once, got %d", calls)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// InClusterInstance is the name of the instance created from the in-cluster configuration.
+const InClusterInstance = "in-cluster"
+
+// Instance is a cluster managed by the adapter. It is named after its kubeconfig context.
+type Instance struct {
+	Name              string
+	KubeClient        kubernetes.Interface
+	DynamicKubeClient dynamic.Interface
+	RESTMapper        *restmapper.DeferredDiscoveryRESTMapper
+	// KubeConfigPath is the path of the kubeconfig file of the instance, empty if it has not been written, see BaseHandler.WriteKubeconfig.
+	KubeConfigPath string
+
+	config          *rest.Config
+	discoveryClient discovery.CachedDiscoveryInterface
+	schemas         *schemaCache
+}
+
+func newInstance(name string, config *rest.Config, kubeConfigPath string) (*Instance, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, ErrClientSet(err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	discoveryClient, err := newDiscoveryClient(config)
+	if err != nil {
+		return nil, ErrClientSet(err)
+	}
+
+	return &Instance{
+		Name:              name,
+		KubeClient:        clientset,
+		DynamicKubeClient: dynamicClient,
+		RESTMapper:        restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient),
+		KubeConfigPath:    kubeConfigPath,
+		config:            config,
+		discoveryClient:   discoveryClient,
+		schemas:           &schemaCache{},
+	}, nil
+}
+
+// impersonate returns a copy of the instance whose clients impersonate the given user and groups.
+// Discovery information and OpenAPI schemas are shared with the instance.
+func (i *Instance) impersonate(userName string, groups []string) (*Instance, error) {
+	config := rest.CopyConfig(i.config)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: userName,
+		Groups:   groups,
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, ErrClientSet(err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	impersonated := *i
+	impersonated.KubeClient = clientset
+	impersonated.DynamicKubeClient = dynamicClient
+	impersonated.config = config
+	return &impersonated, nil
+}
+
+// instanceRegistry holds the instances of the adapter by name. The default instance is the instance added last,
+// when it is removed, the instance added before it becomes the default instance. It is safe for concurrent use.
+type instanceRegistry struct {
+	mu        sync.RWMutex
+	instances map[string]*Instance
+	// order holds the names of the instances in the order they have been added, the default instance is the last one.
+	order []string
+}
+
+// add registers the instance, replacing an instance with the same name, and makes it the default instance.
+// setDefault is called with the instance while the registry is locked.
+func (r *instanceRegistry) add(instance *Instance, setDefault func(*Instance)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.instances == nil {
+		r.instances = make(map[string]*Instance)
+	}
+	r.instances[instance.Name] = instance
+	r.order = append(r.without(instance.Name), instance.Name)
+	setDefault(instance)
+}
+
+// without returns the names of the instances in order, without the given name. The caller must hold r.mu.
+func (r *instanceRegistry) without(name string) []string {
+	order := make([]string, 0, len(r.order))
+	for _, n := range r.order {
+		if n != name {
+			order = append(order, n)
+		}
+	}
+	return order
+}
+
+// get returns the instance with the given name, or the default instance if the name is empty.
+func (r *instanceRegistry) get(name string) (*Instance, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if name == "" {
+		if len(r.order) == 0 {
+			return nil, false
+		}
+		name = r.order[len(r.order)-1]
+	}
+	instance, ok := r.instances[name]
+	return instance, ok
+}
+
+// remove unregisters the instance, and returns false if it does not exist. If it was the default instance,
+// setDefault is called with the new default instance, nil if there is none, while the registry is locked.
+func (r *instanceRegistry) remove(name string, setDefault func(*Instance)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.instances[name]; !ok {
+		return false
+	}
+	wasDefault := r.order[len(r.order)-1] == name
+	delete(r.instances, name)
+	r.order = r.without(name)
+	if wasDefault {
+		if len(r.order) == 0 {
+			setDefault(nil)
+		} else {
+			setDefault(r.instances[r.order[len(r.order)-1]])
+		}
+	}
+	return true
+}
+
+func (r *instanceRegistry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.instances))
+	for name := range r.instances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Instance returns the instance with the given name, or the default instance, i.e. the instance created last, if the name is empty.
+func (h *BaseHandler) Instance(name string) (*Instance, error) {
+	instance, ok := h.instances.get(name)
+	if !ok {
+		return nil, ErrInstanceNotFound(name)
+	}
+	return instance, nil
+}
+
+// requestInstance returns the instance targeted by the request, impersonating the user of the request if enabled.
+func (h *BaseHandler) requestInstance(request OperationRequest) (*Instance, error) {
+	instance, err := h.Instance(request.Instance)
+	if err != nil {
+		return nil, err
+	}
+	if !h.ImpersonateUser {
+		return instance, nil
+	}
+	if request.Username == "" {
+		return nil, ErrImpersonation(errors.New("the request does not specify a user"))
+	}
+	impersonated, err := instance.impersonate(request.Username, request.UserGroups)
+	if err != nil {
+		return nil, ErrImpersonation(err)
+	}
+	return impersonated, nil
+}
+
+// ListInstances returns the names of the instances of the adapter.
+func (h *BaseHandler) ListInstances() ([]string, error) {
+	return h.instances.names(), nil
+}
+
+// RemoveInstance removes the instance with the given name. The resources in the cluster are not affected.
+// If it is the default instance, the instance created before it becomes the default instance.
+func (h *BaseHandler) RemoveInstance(name string) error {
+	if !h.instances.remove(name, h.setDefaultInstance) {
+		return ErrInstanceNotFound(name)
+	}
+	return nil
+}
+
+// setDefaultInstance sets the client fields of the handler to the clients of the default instance, or clears them if it is nil.
+// KubeClient is only set if the client of the instance is a *kubernetes.Clientset.
+// It is called by the instance registry while it is locked.
+func (h *BaseHandler) setDefaultInstance(instance *Instance) {
+	if instance == nil {
+		h.KubeClient = nil
+		h.DynamicKubeClient = nil
+		h.RESTMapper = nil
+		h.KubeConfigPath = ""
+		return
+	}
+	h.KubeClient, _ = instance.KubeClient.(*kubernetes.Clientset)
+	h.DynamicKubeClient = instance.DynamicKubeClient
+	h.RESTMapper = instance.RESTMapper
+	h.KubeConfigPath = instance.KubeConfigPath
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"testing"
+)
+
+func TestDefaultInstance(t *testing.T) {
+	h := &BaseHandler{}
+	first := &Instance{Name: "first", KubeConfigPath: "/first"}
+	second := &Instance{Name: "second", KubeConfigPath: "/second"}
+	h.instances.add(first, h.setDefaultInstance)
+	h.instances.add(second, h.setDefaultInstance)
+
+	expectDefault := func(want *Instance) {
+		t.Helper()
+		got, err := h.Instance("")
+		if want == nil {
+			if err == nil {
+				t.Errorf("expected no default instance, got %s", got.Name)
+			}
+			if h.KubeConfigPath != "" {
+				t.Errorf("expected the client fields to be cleared, got %s", h.KubeConfigPath)
+			}
+			return
+		}
+		if err != nil || got != want {
+			t.Fatalf("expected default instance %s, got %v (%v)", want.Name, got, err)
+		}
+		if h.KubeConfigPath != want.KubeConfigPath {
+			t.Errorf("expected the client fields of %s, got %s", want.Name, h.KubeConfigPath)
+		}
+	}
+
+	expectDefault(second)
+	if err := h.RemoveInstance("first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectDefault(second)
+
+	h.instances.add(first, h.setDefaultInstance)
+	h.instances.add(second, h.setDefaultInstance) // replacing an instance makes it the default instance again
+	expectDefault(second)
+	if err := h.RemoveInstance("second"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectDefault(first)
+
+	if err := h.RemoveInstance("second"); err == nil {
+		t.Error("expected removing an unknown instance to fail")
+	}
+	if err := h.RemoveInstance("first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectDefault(nil)
+}
@@ -0,0 +1,212 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// JobState is the state of an operation run by a JobRunner.
+type JobState string
+
+const (
+	JobPending   JobState = "pending"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobCanceled  JobState = "canceled"
+)
+
+const (
+	// DefaultJobWorkers is the default number of operations run concurrently by a JobRunner.
+	DefaultJobWorkers = 4
+	// DefaultJobQueueSize is the default number of operations a JobRunner accepts while all workers are busy.
+	DefaultJobQueueSize = 100
+	// JobRetention is the time the state of finished jobs is kept.
+	JobRetention = time.Hour
+)
+
+// Job is the state of an operation run by a JobRunner.
+type Job struct {
+	OperationID   string    `json:"operationid,omitempty"`
+	OperationName string    `json:"operationname,omitempty"`
+	State         JobState  `json:"state,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	Submitted     time.Time `json:"submitted,omitempty"`
+	Started       time.Time `json:"started,omitempty"`
+	Finished      time.Time `json:"finished,omitempty"`
+	// Result is the outcome of the operation, see OperationRequest.Result.
+	Result *OperationResult `json:"-"`
+}
+
+// ApplyFunc applies an operation, e.g. Handler.ApplyOperation.
+type ApplyFunc func(context.Context, OperationRequest) error
+
+type queuedJob struct {
+	request OperationRequest
+	apply   ApplyFunc
+}
+
+// JobRunner runs operations asynchronously with a bounded number of workers, and tracks their state.
+// It is safe for concurrent use.
+type JobRunner struct {
+	queue   chan queuedJob
+	cancels CancelRegistry
+
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// NewJobRunner creates a job runner running up to the given number of operations concurrently, and accepting up to
+// queueSize operations while all workers are busy. Defaults are used for values less than 1.
+func NewJobRunner(workers, queueSize int) *JobRunner {
+	if workers < 1 {
+		workers = DefaultJobWorkers
+	}
+	if queueSize < 1 {
+		queueSize = DefaultJobQueueSize
+	}
+	r := &JobRunner{
+		queue: make(chan queuedJob, queueSize),
+		jobs:  make(map[string]*Job),
+	}
+	for i := 0; i < workers; i++ {
+		go r.work()
+	}
+	return r
+}
+
+// Submit queues the operation, and returns its operation ID. An operation ID is generated if the request has none.
+// If a job with the same operation ID is known, the operation is not queued again, its state is the state of that job.
+// ErrJobQueueFull is returned if the queue is full.
+func (r *JobRunner) Submit(request OperationRequest, apply ApplyFunc) (string, error) {
+	if request.OperationID == "" {
+		request.OperationID = uuid.New().String()
+	}
+	if request.Result == nil {
+		request.Result = &OperationResult{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire()
+	if _, ok := r.jobs[request.OperationID]; ok {
+		return request.OperationID, nil
+	}
+
+	select {
+	case r.queue <- queuedJob{request: request, apply: apply}:
+	default:
+		return "", ErrJobQueueFull
+	}
+	r.jobs[request.OperationID] = &Job{
+		OperationID:   request.OperationID,
+		OperationName: request.OperationName,
+		State:         JobPending,
+		Submitted:     time.Now(),
+		Result:        request.Result,
+	}
+	return request.OperationID, nil
+}
+
+// Job returns the state of the job with the given operation ID.
+func (r *JobRunner) Job(operationID string) (Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[operationID]
+	if !ok {
+		return Job{}, ErrJobNotFound(operationID)
+	}
+	return *job, nil
+}
+
+// Cancel cancels the pending or running job with the given operation ID, and returns false if there is none.
+func (r *JobRunner) Cancel(operationID string) bool {
+	r.mu.Lock()
+	job, ok := r.jobs[operationID]
+	if ok && job.State == JobPending {
+		job.State = JobCanceled
+		job.Finished = time.Now()
+		r.mu.Unlock()
+		return true
+	}
+	r.mu.Unlock()
+	return ok && r.cancels.Cancel(operationID)
+}
+
+func (r *JobRunner) work() {
+	for queued := range r.queue {
+		r.run(queued)
+	}
+}
+
+func (r *JobRunner) run(queued queuedJob) {
+	id := queued.request.OperationID
+	ctx, done := r.cancels.WithCancel(context.Background(), id)
+	defer done()
+
+	canceled := false
+	r.update(id, func(job *Job) {
+		canceled = job.State == JobCanceled
+		if !canceled {
+			job.State = JobRunning
+			job.Started = time.Now()
+		}
+	})
+	if canceled {
+		return
+	}
+
+	err := queued.apply(ctx, queued.request.WithContext(ctx))
+	if err != nil {
+		logrus.Errorf("operation %s (%s) failed: %v", queued.request.OperationName, id, err)
+	}
+
+	r.update(id, func(job *Job) {
+		job.Finished = time.Now()
+		switch {
+		case err == nil:
+			job.State = JobSucceeded
+		case ctx.Err() == context.Canceled:
+			job.State = JobCanceled
+			job.Error = err.Error()
+		default:
+			job.State = JobFailed
+			job.Error = err.Error()
+		}
+	})
+}
+
+func (r *JobRunner) update(operationID string, update func(*Job)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job, ok := r.jobs[operationID]; ok {
+		update(job)
+	}
+}
+
+// expire removes finished jobs older than JobRetention. The caller must hold r.mu for writing.
+func (r *JobRunner) expire() {
+	for id, job := range r.jobs {
+		if !job.Finished.IsZero() && time.Since(job.Finished) > JobRetention {
+			delete(r.jobs, id)
+		}
+	}
+}
@@ -23,7 +23,12 @@ import (
 	"github.com/mgfeller/common-adapter-library/meshes"
 
 	"io"
+	"io/fs"
 	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"text/template"
 	"time"
@@ -37,6 +42,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,22 +51,45 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-func (h *BaseHandler) k8sClientConfig(kubeconfig []byte, contextName string) (*rest.Config, error) {
+// k8sClientConfig returns the client config of the given kubeconfig context, or of the current context if no context
+// name is given, together with the name of the context and the path of the kubeconfig file, if it is written.
+// The in-cluster config is returned if no kubeconfig is given.
+func (h *BaseHandler) k8sClientConfig(kubeconfig []byte, contextName string) (*rest.Config, string, string, error) {
 	if len(kubeconfig) > 0 {
 		ccfg, err := clientcmd.Load(kubeconfig)
 		if err != nil {
-			return nil, err
+			return nil, "", "", err
 		}
 		if contextName != "" {
 			ccfg.CurrentContext = contextName
 		}
-		err = writeKubeconfig(kubeconfig, contextName, h.KubeConfigPath)
-		if err != nil {
-			return nil, err
+		path := ""
+		if h.WriteKubeconfig {
+			path, err = instanceKubeconfigPath(h.Config.GetKey("kube-config-path"), ccfg.CurrentContext)
+			if err != nil {
+				return nil, "", "", err
+			}
+			err = writeKubeconfig(kubeconfig, ccfg.CurrentContext, path)
+			if err != nil {
+				return nil, "", "", err
+			}
 		}
-		return clientcmd.NewDefaultClientConfig(*ccfg, &clientcmd.ConfigOverrides{}).ClientConfig()
+		config, err := clientcmd.NewDefaultClientConfig(*ccfg, &clientcmd.ConfigOverrides{}).ClientConfig()
+		return config, ccfg.CurrentContext, path, err
 	}
-	return rest.InClusterConfig()
+	config, err := rest.InClusterConfig()
+	return config, InClusterInstance, "", err
+}
+
+// instanceKubeconfigPath returns the path of the kubeconfig of an instance, in a directory named after the instance
+// next to the configured kubeconfig path, e.g. /root/.kube/<instance>/config for /root/.kube/config. The name of the directory
+// is the path escaped instance name, such that different instances have different directories.
+func instanceKubeconfigPath(path string, instanceName string) (string, error) {
+	dir := url.PathEscape(instanceName)
+	if dir == "" || dir == "." || dir == ".." {
+		return "", fmt.Errorf("unable to write the kubeconfig of instance %q: invalid name", instanceName)
+	}
+	return filepath.Join(filepath.Dir(path), dir, filepath.Base(path)), nil
 }
 
 // writeKubeconfig creates kubeconfig in local container or file system
@@ -78,6 +107,11 @@ func writeKubeconfig(kubeconfig []byte, contextName string, path string) error {
 		return err
 	}
 
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
 	err = ioutil.WriteFile(path, d, 0600)
 	if err != nil {
 		return err
@@ -86,75 +120,124 @@ func writeKubeconfig(kubeconfig []byte, contextName string, path string) error {
 	return nil
 }
 
-func (h *BaseHandler) executeRule(ctx context.Context, data *unstructured.Unstructured, namespace string, isDelete, isCustomOp bool) error {
-	if namespace != "" {
-		data.SetNamespace(namespace)
+// executeRule applies a single object, and returns its outcome. The outcome of dry runs is empty, see previewResource.
+func (h *BaseHandler) executeRule(ctx context.Context, data *unstructured.Unstructured, opts applyOptions) (ObjectOutcome, error) {
+	mapping, err := opts.instance.resourceMapping(data)
+	if err != nil {
+		if opts.isDelete && meta.IsNoMatchError(gherrors.Cause(err)) { // the kind, and hence the resource, does not exist anymore
+			logrus.Infof("Skipping deletion of resource of unknown type: %s and name: %s", data.GetKind(), data.GetName())
+			return ObjectSkippedMissing, nil
+		}
+		return ObjectFailed, err
 	}
-	groupVersion := strings.Split(data.GetAPIVersion(), "/")
-	logrus.Debugf("groupVersion: %v", groupVersion)
-	var group, version string
-	if len(groupVersion) == 2 {
-		group = groupVersion[0]
-		version = groupVersion[1]
-	} else if len(groupVersion) == 1 {
-		version = groupVersion[0]
+	if !isNamespaced(mapping) {
+		data.SetNamespace("")
+	} else if opts.namespace != "" {
+		data.SetNamespace(opts.namespace)
 	}
 
-	kind := strings.ToLower(data.GetKind())
-	switch kind {
-	case "logentry":
-		kind = "logentries"
-	case "kubernetes":
-		kind = "kuberneteses"
-	default:
-		kind += "s"
+	res := mapping.Resource
+	logrus.Debugf("Computed Resource: %+#v", res)
+
+	if !opts.isDelete {
+		opts.owner.stamp(data)
 	}
 
-	res := schema.GroupVersionResource{
-		Group:    group,
-		Version:  version,
-		Resource: kind,
+	if opts.dryRun {
+		return "", h.previewResource(ctx, res, data, opts)
 	}
-	logrus.Debugf("Computed Resource: %+#v", res)
 
-	if isDelete {
-		return h.deleteResource(ctx, res, data)
+	if opts.isDelete {
+		if err := opts.instance.deleteResource(ctx, res, data); err != nil {
+			return ObjectFailed, err
+		}
+		return ObjectDeleted, nil
+	}
+
+	opts.resources.add(res, data)
+
+	outcome, err := h.writeResource(ctx, res, data, opts)
+	if err != nil {
+		return ObjectFailed, err
 	}
 
-	if err := h.createResource(ctx, res, data); err != nil {
-		if isCustomOp {
-			if err := h.deleteResource(ctx, res, data); err != nil {
-				return err
+	// custom resources can only be created once their definition is established
+	if data.GroupVersionKind().GroupKind() == crdGroupKind {
+		if err := h.waitForReadiness(ctx, opts.instance, []appliedResource{{resource: res, object: data}}, crdEstablishedTimeout, opts.operationID); err != nil {
+			return ObjectFailed, err
+		}
+	}
+	return outcome, nil
+}
+
+func (h *BaseHandler) writeResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured, opts applyOptions) (ObjectOutcome, error) {
+	var previous *unstructured.Unstructured
+	if opts.journal != nil {
+		existing, err := opts.instance.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Get(ctx, data.GetName(), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			err = gherrors.Wrapf(err, "unable to retrieve the current state of the resource")
+			logrus.Error(err)
+			return ObjectFailed, err
+		}
+		if err == nil {
+			previous = existing
+		}
+	}
+
+	if opts.applyMode == ApplyModeServerSide {
+		if err := opts.instance.applyResource(ctx, res, data, h.fieldManager()); err != nil {
+			return ObjectFailed, err
+		}
+		opts.journal.record(res, data, previous)
+		return ObjectApplied, nil
+	}
+
+	if err := opts.instance.createResource(ctx, res, data); err != nil {
+		if opts.isCustomOp && apierrors.IsAlreadyExists(gherrors.Cause(err)) { // custom operations replace existing resources
+			// recorded before the resource is deleted, such that it is restored by a rollback if the recreation fails
+			opts.journal.record(res, data, previous)
+			if err := opts.instance.deleteResource(ctx, res, data); err != nil {
+				return ObjectFailed, err
 			}
-			time.Sleep(time.Second)
-			if err := h.createResource(ctx, res, data); err != nil {
-				return err
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				return ObjectFailed, ctx.Err()
 			}
-		} else {
-			return err
+			if err := opts.instance.createResource(ctx, res, data); err != nil {
+				return ObjectFailed, err
+			}
+			return ObjectCreated, nil
 		}
+		return ObjectFailed, err
 	}
-	return nil
+	opts.journal.record(res, data, previous)
+	return ObjectCreated, nil
 }
 
-func (h *BaseHandler) createResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) error {
-	_, err := h.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Create(ctx, data, metav1.CreateOptions{})
-	if err != nil {
-		err = gherrors.Wrapf(err, "unable to create the requested resource, attempting operation without namespace")
-		logrus.Warn(err)
-		_, err = h.DynamicKubeClient.Resource(res).Create(ctx, data, metav1.CreateOptions{})
-		if err != nil {
-			err = gherrors.Wrapf(err, "unable to create the requested resource, attempting to update")
-			logrus.Error(err)
+func (i *Instance) createResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) error {
+	err := retry(ctx, fmt.Sprintf("create %s %s", data.GetKind(), data.GetName()), func() error {
+		_, err := i.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Create(ctx, data, metav1.CreateOptions{})
+		return err
+	})
+	if namespaceFallback(data, err) {
+		logrus.Warn(gherrors.Wrapf(err, "unable to create the requested resource, attempting operation without namespace"))
+		err = fallbackError(err, retry(ctx, fmt.Sprintf("create %s %s", data.GetKind(), data.GetName()), func() error {
+			_, err := i.DynamicKubeClient.Resource(res).Create(ctx, data, metav1.CreateOptions{})
 			return err
-		}
+		}))
+	}
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to create the requested resource")
+		logrus.Error(err)
+		return err
 	}
 	logrus.Infof("Created Resource of type: %s and name: %s", data.GetKind(), data.GetName())
 	return nil
 }
 
-func (h *BaseHandler) deleteResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) error {
-	if h.DynamicKubeClient == nil {
+func (i *Instance) deleteResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) error {
+	if i.DynamicKubeClient == nil {
 		return errors.New("mesh client has not been created")
 	}
 
@@ -164,7 +247,7 @@ func (h *BaseHandler) deleteResource(ctx context.Context, res schema.GroupVersio
 
 	// in the case with deployments, have to scale it down to 0 first and then delete. . . or else RS and pods will be left behind
 	if res.Resource == "deployments" {
-		data1, err := h.getResource(ctx, res, data)
+		data1, err := i.getResource(ctx, res, data)
 		if err != nil {
 			return err
 		}
@@ -172,96 +255,149 @@ func (h *BaseHandler) deleteResource(ctx context.Context, res schema.GroupVersio
 		spec1 := depl["spec"].(map[string]interface{})
 		spec1["replicas"] = 0
 		data1.SetUnstructuredContent(depl)
-		if err = h.updateResource(ctx, res, data1); err != nil {
+		if err = i.updateResource(ctx, res, data1); err != nil {
 			return err
 		}
 	}
 
-	err := h.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Delete(ctx, data.GetName(), metav1.DeleteOptions{})
+	err := retry(ctx, fmt.Sprintf("delete %s %s", data.GetKind(), data.GetName()), func() error {
+		return i.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Delete(ctx, data.GetName(), metav1.DeleteOptions{})
+	})
+	if namespaceFallback(data, err) {
+		logrus.Warn(gherrors.Wrapf(err, "unable to delete the requested resource, attempting operation without namespace"))
+		err = fallbackError(err, retry(ctx, fmt.Sprintf("delete %s %s", data.GetKind(), data.GetName()), func() error {
+			return i.DynamicKubeClient.Resource(res).Delete(ctx, data.GetName(), metav1.DeleteOptions{})
+		}))
+	}
 	if err != nil {
-		err = gherrors.Wrapf(err, "unable to delete the requested resource, attempting operation without namespace")
-		logrus.Warn(err)
-
-		err := h.DynamicKubeClient.Resource(res).Delete(ctx, data.GetName(), metav1.DeleteOptions{})
-		if err != nil {
-			err = gherrors.Wrapf(err, "unable to delete the requested resource")
-			logrus.Error(err)
-			return err
-		}
+		err = gherrors.Wrapf(err, "unable to delete the requested resource")
+		logrus.Error(err)
+		return err
 	}
 	logrus.Infof("Deleted Resource of type: %s and name: %s", data.GetKind(), data.GetName())
 	return nil
 }
 
-func (h *BaseHandler) getResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	data1, err := h.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Get(ctx, data.GetName(), metav1.GetOptions{})
+func (i *Instance) getResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var data1 *unstructured.Unstructured
+	err := retry(ctx, fmt.Sprintf("get %s %s", data.GetKind(), data.GetName()), func() (err error) {
+		data1, err = i.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Get(ctx, data.GetName(), metav1.GetOptions{})
+		return err
+	})
+	if namespaceFallback(data, err) {
+		logrus.Warn(gherrors.Wrap(err, "unable to retrieve the resource with a matching name, attempting operation without namespace"))
+		err = fallbackError(err, retry(ctx, fmt.Sprintf("get %s %s", data.GetKind(), data.GetName()), func() (err error) {
+			data1, err = i.DynamicKubeClient.Resource(res).Get(ctx, data.GetName(), metav1.GetOptions{})
+			return err
+		}))
+	}
 	if err != nil {
-		err = gherrors.Wrap(err, "unable to retrieve the resource with a matching name, attempting operation without namespace")
-		logrus.Warn(err)
-
-		data1, err = h.DynamicKubeClient.Resource(res).Get(ctx, data.GetName(), metav1.GetOptions{})
-		if err != nil {
-			err = gherrors.Wrap(err, "unable to retrieve the resource with a matching name, while attempting to apply the config")
-			logrus.Error(err)
-			return nil, err
-		}
+		err = gherrors.Wrap(err, "unable to retrieve the resource with a matching name, while attempting to apply the config")
+		logrus.Error(err)
+		return nil, err
 	}
 	logrus.Infof("Retrieved Resource of type: %s and name: %s", data.GetKind(), data.GetName())
 	return data1, nil
 }
 
-func (h *BaseHandler) updateResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) error {
-	if _, err := h.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Update(ctx, data, metav1.UpdateOptions{}); err != nil {
-		err = gherrors.Wrap(err, "unable to update resource with the given name, attempting operation without namespace")
-		logrus.Warn(err)
+func (i *Instance) updateResource(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured) error {
+	err := retry(ctx, fmt.Sprintf("update %s %s", data.GetKind(), data.GetName()), func() error {
+		_, err := i.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Update(ctx, data, metav1.UpdateOptions{})
+		i.refreshResourceVersion(ctx, res, data, err)
+		return err
+	})
+	if namespaceFallback(data, err) {
+		logrus.Warn(gherrors.Wrap(err, "unable to update resource with the given name, attempting operation without namespace"))
+		err = fallbackError(err, retry(ctx, fmt.Sprintf("update %s %s", data.GetKind(), data.GetName()), func() error {
+			_, err := i.DynamicKubeClient.Resource(res).Update(ctx, data, metav1.UpdateOptions{})
+			i.refreshResourceVersion(ctx, res, data, err)
+			return err
+		}))
+	}
+	if err != nil {
+		err = gherrors.Wrap(err, "unable to update resource with the given name, while attempting to apply the config")
+		logrus.Error(err)
+		return err
+	}
+	logrus.Infof("Updated Resource of type: %s and name: %s", data.GetKind(), data.GetName())
+	return nil
+}
 
-		if _, err = h.DynamicKubeClient.Resource(res).Update(ctx, data, metav1.UpdateOptions{}); err != nil {
-			err = gherrors.Wrap(err, "unable to update resource with the given name, while attempting to apply the config")
-			logrus.Error(err)
+// refreshResourceVersion sets the current resource version of the resource if the update failed with a conflict,
+// such that the update can be retried.
+func (i *Instance) refreshResourceVersion(ctx context.Context, res schema.GroupVersionResource, data *unstructured.Unstructured, err error) {
+	if !apierrors.IsConflict(err) {
+		return
+	}
+	current, getErr := i.DynamicKubeClient.Resource(res).Namespace(data.GetNamespace()).Get(ctx, data.GetName(), metav1.GetOptions{})
+	if getErr != nil {
+		return
+	}
+	data.SetResourceVersion(current.GetResourceVersion())
+}
+
+func (h *BaseHandler) applyConfigChange(ctx context.Context, yamlFileContents string, opts applyOptions) error {
+	if opts.instance == nil || opts.instance.DynamicKubeClient == nil {
+		return errors.New("mesh client has not been created")
+	}
+	objects, err := h.decodeManifest(yamlFileContents, opts.isDelete)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range objects {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		outcome, err := h.executeRule(ctx, data, opts)
+		if err != nil {
+			cause := gherrors.Cause(err)
+			switch {
+			case opts.isDelete && apierrors.IsNotFound(cause):
+				outcome, err = ObjectSkippedMissing, nil
+			case !opts.isDelete && apierrors.IsAlreadyExists(cause):
+				outcome, err = ObjectUnchanged, nil
+			}
+		}
+		if outcome != "" {
+			opts.result.addObject(data, outcome, err)
+		}
+		if err != nil {
 			return err
 		}
 	}
-	logrus.Infof("Updated Resource of type: %s and name: %s", data.GetKind(), data.GetName())
 	return nil
 }
 
-func (h *BaseHandler) applyConfigChange(ctx context.Context, yamlFileContents, namespace string, isDelete, isCustomOp bool) error {
+// decodeManifest decodes the objects of all documents of the manifest, in the order they have to be applied.
+func (h *BaseHandler) decodeManifest(yamlFileContents string, isDelete bool) ([]*unstructured.Unstructured, error) {
 	yamls, err := h.splitYAML(yamlFileContents)
 	if err != nil {
 		err = gherrors.Wrap(err, "error while splitting yaml")
 		logrus.Error(err)
-		return err
+		return nil, err
 	}
+	objects := make([]*unstructured.Unstructured, 0, len(yamls))
 	for _, yml := range yamls {
 		if strings.TrimSpace(yml) != "" {
-			if err := h.applyRulePayload(ctx, namespace, []byte(yml), isDelete, isCustomOp); err != nil {
-				errStr := strings.TrimSpace(err.Error())
-				if isDelete {
-					if strings.HasSuffix(errStr, "not found") ||
-						strings.HasSuffix(errStr, "the server could not find the requested resource") {
-						continue
-					}
-				} else {
-					if strings.HasSuffix(errStr, "already exists") {
-						continue
-					}
-				}
-				return err
+			data, err := h.decodeRulePayload([]byte(yml))
+			if err != nil {
+				return nil, err
 			}
+			objects = append(objects, data...)
 		}
 	}
-	return nil
+	sortByKind(objects, isDelete)
+	return objects, nil
 }
 
-func (h *BaseHandler) applyRulePayload(ctx context.Context, namespace string, newBytes []byte, isDelete, isCustomOp bool) error {
-	if h.DynamicKubeClient == nil {
-		return errors.New("mesh client has not been created")
-	}
+// decodeRulePayload decodes a YAML document into its objects, the items of a list are returned individually.
+func (h *BaseHandler) decodeRulePayload(newBytes []byte) ([]*unstructured.Unstructured, error) {
 	jsonBytes, err := yaml.YAMLToJSON(newBytes)
 	if err != nil {
 		err = gherrors.Wrapf(err, "unable to convert yaml to json")
 		logrus.Error(err)
-		return err
+		return nil, err
 	}
 	if len(jsonBytes) > 5 { // attempting to skip 'null' json
 		data := &unstructured.Unstructured{}
@@ -269,18 +405,20 @@ func (h *BaseHandler) applyRulePayload(ctx context.Context, namespace string, ne
 		if err != nil {
 			err = gherrors.Wrapf(err, "unable to unmarshal json created from yaml")
 			logrus.Error(err)
-			return err
+			return nil, err
 		}
 		if data.IsList() {
+			objects := []*unstructured.Unstructured{}
 			err = data.EachListItem(func(r runtime.Object) error {
 				dataL, _ := r.(*unstructured.Unstructured)
-				return h.executeRule(ctx, dataL, namespace, isDelete, isCustomOp)
+				objects = append(objects, dataL)
+				return nil
 			})
-			return err
+			return objects, err
 		}
-		return h.executeRule(ctx, data, namespace, isDelete, isCustomOp)
+		return []*unstructured.Unstructured{data}, nil
 	}
-	return nil
+	return nil, nil
 }
 
 func (h *BaseHandler) splitYAML(yamlContents string) ([]string, error) {
@@ -324,24 +462,51 @@ func (h *BaseHandler) splitYAML(yamlContents string) ([]string, error) {
 }
 
 // creates the namespace if it doesn't exist
-func (h *BaseHandler) createNamespace(ctx context.Context, namespace string) error {
+// createNamespace creates the namespace if it does not exist, and returns true if it has been created.
+// With dry run the creation is only previewed using server-side dry-run.
+func (i *Instance) createNamespace(ctx context.Context, namespace string, dryRun bool) (bool, error) {
 	logrus.Debugf("creating namespace: %s", namespace)
-	_, errGetNs := h.KubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	_, errGetNs := i.KubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 	if apierrors.IsNotFound(errGetNs) {
 		nsSpec := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
-		_, err := h.KubeClient.CoreV1().Namespaces().Create(ctx, nsSpec, metav1.CreateOptions{})
-		return err
+		opts := metav1.CreateOptions{}
+		if dryRun {
+			opts.DryRun = []string{metav1.DryRunAll}
+		}
+		if _, err := i.KubeClient.CoreV1().Namespaces().Create(ctx, nsSpec, opts); err != nil {
+			return false, err
+		}
+		return true, nil
 	}
-	return errGetNs
+	return false, errGetNs
 }
 
-func (h *BaseHandler) executeTemplate(ctx context.Context, data map[string]string, templatePath string) (string, error) {
-	tmpl, err := template.ParseFiles(templatePath)
+// executeTemplate executes the template with the given data. Partials in the directory of the template,
+// files with names starting with an underscore, are parsed with the template, and can be used with 'include'.
+// The template is loaded from the Templates file system of the handler, or from the OS file system if it is not set.
+func (h *BaseHandler) executeTemplate(ctx context.Context, data interface{}, templatePath string) (string, error) {
+	fsys, name := h.templateSource(templatePath)
+	tmpl := template.New(path.Base(name))
+	tmpl.Funcs(templateFuncs(tmpl))
+	tmpl, err := tmpl.ParseFS(fsys, name)
 	if err != nil {
 		err = gherrors.Wrapf(err, "unable to parse template")
 		logrus.Error(err)
 		return "", err
 	}
+	partials, err := fs.Glob(fsys, path.Join(path.Dir(name), "_*"))
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to find partials")
+		logrus.Error(err)
+		return "", err
+	}
+	if len(partials) > 0 {
+		if _, err := tmpl.ParseFS(fsys, partials...); err != nil {
+			err = gherrors.Wrapf(err, "unable to parse partials")
+			logrus.Error(err)
+			return "", err
+		}
+	}
 	buf := bytes.NewBufferString("")
 	err = tmpl.Execute(buf, data)
 	if err != nil {
@@ -352,21 +517,129 @@ func (h *BaseHandler) executeTemplate(ctx context.Context, data map[string]strin
 	return buf.String(), nil
 }
 
-func (h *BaseHandler) applyK8sManifest(ctx context.Context, request OperationRequest, operation Operation, data map[string]string, templatePath string) error {
-	merged, err := h.executeTemplate(ctx, data, templatePath)
+func (h *BaseHandler) applyK8sManifest(ctx context.Context, request OperationRequest, operation Operation, data interface{}, templatePath string) error {
+	return h.applyManifest(ctx, request, operation, h.ApplyMode, func(instance *Instance) (string, error) {
+		merged, err := h.executeTemplate(ctx, data, templatePath)
+		if err != nil {
+			return "", gherrors.Wrapf(err, "unable to apply kubernetes manifest (executeTemplate) ")
+		}
+		return merged, nil
+	})
+}
+
+// applyManifest applies the manifest rendered for the instance targeted by the request, writing resources with the given apply mode.
+func (h *BaseHandler) applyManifest(ctx context.Context, request OperationRequest, operation Operation, mode ApplyMode, render func(*Instance) (string, error)) error {
+	instance, err := h.requestInstance(request)
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (instance)")
+		logrus.Error(err)
+		return err
+	}
+
+	timeout, err := operation.readinessTimeout()
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (readinessTimeout)")
+		logrus.Error(err)
+		return err
+	}
+	rollback, err := operation.rollback()
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (rollback)")
+		logrus.Error(err)
+		return err
+	}
+	prune, err := operation.prune()
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (prune)")
+		logrus.Error(err)
+		return err
+	}
+	ctx, cancel, err := operation.withTimeout(ctx)
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (timeout)")
+		logrus.Error(err)
+		return err
+	}
+	defer cancel()
+	ctx = withRetrier(ctx, h.retrier(request.OperationID))
+
+	if h.Locks != nil && !request.DryRun {
+		lockCtx, unlock, err := h.Locks.Lock(ctx, instance, request.Namespace, request.OperationID)
+		if err != nil {
+			err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (lock)")
+			logrus.Error(err)
+			return err
+		}
+		defer unlock()
+		ctx = lockCtx
+	}
+
+	if prune && request.IsDeleteOperation && !request.DryRun {
+		if err := h.pruneResources(ctx, instance, h.ownership(request), request.Namespace, request.OperationID); err != nil {
+			err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (pruneResources)")
+			logrus.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	merged, err := render(instance)
 	if err != nil {
-		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (executeTemplate) ")
 		logrus.Error(err)
 		return err
 	}
 
-	isCustomOperation := operation.Type == int32(meshes.OpCategory_CUSTOM)
+	opts := applyOptions{
+		instance:    instance,
+		namespace:   request.Namespace,
+		isDelete:    request.IsDeleteOperation,
+		isCustomOp:  operation.Type == int32(meshes.OpCategory_CUSTOM),
+		dryRun:      request.DryRun,
+		applyMode:   mode,
+		operationID: request.OperationID,
+		owner:       h.ownership(request),
+		result:      request.Result,
+		resources:   &resourceList{},
+	}
+	if rollback && !opts.isDelete && !opts.dryRun {
+		opts.journal = &journal{}
+	}
 
-	if err := h.applyConfigChange(ctx, merged, request.Namespace, request.IsDeleteOperation, isCustomOperation); err != nil {
+	if h.ValidateManifests && !opts.isDelete {
+		if err := h.validateManifest(merged, opts); err != nil {
+			err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (validateManifest)")
+			logrus.Error(err)
+			return err
+		}
+	}
+
+	if h.CheckPermissions {
+		if err := h.checkPermissions(ctx, merged, opts, timeout > 0); err != nil {
+			err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (checkPermissions)")
+			logrus.Error(err)
+			return err
+		}
+	}
+
+	if err := h.applyConfigChange(ctx, merged, opts); err != nil {
 		err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (applyConfigChange)")
 		logrus.Error(err)
+		if rollbackErr := h.rollback(ctx, instance, opts.journal, request.OperationID); rollbackErr != nil {
+			err = gherrors.WithMessage(err, rollbackErr.Error())
+		}
 		return err
 	}
 
+	if timeout > 0 && !opts.isDelete && !opts.dryRun {
+		if err := h.waitForReadiness(ctx, instance, opts.resources.list(), timeout, request.OperationID); err != nil {
+			err = gherrors.Wrapf(err, "unable to apply kubernetes manifest (waitForReadiness)")
+			logrus.Error(err)
+			if rollbackErr := h.rollback(ctx, instance, opts.journal, request.OperationID); rollbackErr != nil {
+				err = gherrors.WithMessage(err, rollbackErr.Error())
+			}
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,52 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestInstanceKubeconfigPath(t *testing.T) {
+	kubeconfig := filepath.Join("/root", ".kube", "config")
+	tests := []struct {
+		instance string
+		want     string
+	}{
+		{instance: "kind-kind", want: filepath.Join("/root", ".kube", "kind-kind", "config")},
+		{instance: "arn:aws:eks:eu-west-1:1:cluster/prod", want: filepath.Join("/root", ".kube", "arn:aws:eks:eu-west-1:1:cluster%2Fprod", "config")},
+		{instance: "a/b", want: filepath.Join("/root", ".kube", "a%2Fb", "config")},
+		{instance: "a_b", want: filepath.Join("/root", ".kube", "a_b", "config")},
+		{instance: "a%2Fb", want: filepath.Join("/root", ".kube", "a%252Fb", "config")},
+		{instance: "../..", want: filepath.Join("/root", ".kube", "..%2F..", "config")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			got, err := instanceKubeconfigPath(kubeconfig, tt.instance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	for _, instance := range []string{"", ".", ".."} {
+		if path, err := instanceKubeconfigPath(kubeconfig, instance); err == nil {
+			t.Errorf("expected instance %q to be rejected, got %s", instance, path)
+		}
+	}
+}
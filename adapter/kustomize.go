@@ -0,0 +1,151 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+// ApplyKustomization builds the kustomization in the directory kustomizationPath, and applies the resulting manifest
+// like ApplyKubernetesManifest. The namespace, images and common labels of the kustomization can be set with
+// the operation properties KustomizeNamespaceKey, and the ones prefixed with KustomizeImagePrefix and KustomizeLabelPrefix.
+//
+// The namespace of the kustomization takes precedence over the namespace of the request, it is created like the namespace of the operation.
+func (h *BaseHandler) ApplyKustomization(request OperationRequest, operation Operation, kustomizationPath string) error {
+	if namespaced := kustomizeRequest(request, operation); namespaced.Namespace != request.Namespace {
+		request = namespaced
+		if err := h.CreateOperationNamespace(request); err != nil {
+			err = gherrors.Wrapf(err, "unable to apply kustomization (CreateOperationNamespace)")
+			logrus.Error(err)
+			return err
+		}
+	}
+	err := h.applyManifest(request.Context(), request, operation, h.ApplyMode, func(instance *Instance) (string, error) {
+		manifest, err := h.buildKustomization(operation, kustomizationPath)
+		if err != nil {
+			return "", gherrors.Wrapf(err, "unable to apply kustomization (buildKustomization)")
+		}
+		return manifest, nil
+	})
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// buildKustomization builds the kustomization, using an overlay if the operation customizes it.
+func (h *BaseHandler) buildKustomization(operation Operation, kustomizationPath string) (string, error) {
+	path, err := filepath.Abs(kustomizationPath)
+	if err != nil {
+		return "", err
+	}
+
+	overlay := operation.kustomization()
+	if overlay != nil {
+		dir, err := ioutil.TempDir("", "kustomization")
+		if err != nil {
+			return "", gherrors.Wrapf(err, "unable to create kustomization overlay")
+		}
+		defer os.RemoveAll(dir)
+
+		base, err := filepath.Rel(dir, path) // kustomize only accepts relative paths of resources
+		if err != nil {
+			return "", err
+		}
+		overlay.Resources = []string{base}
+		content, err := yaml.Marshal(overlay)
+		if err != nil {
+			return "", err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, konfig.DefaultKustomizationFileName()), content, 0600); err != nil {
+			return "", gherrors.Wrapf(err, "unable to write kustomization overlay")
+		}
+		path = dir
+	}
+
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), path)
+	if err != nil {
+		return "", gherrors.Wrapf(err, "unable to build kustomization %s", kustomizationPath)
+	}
+	manifest, err := resources.AsYaml()
+	if err != nil {
+		return "", err
+	}
+	return string(manifest), nil
+}
+
+// kustomizeRequest returns the request with the namespace of the kustomization set in the operation properties, if any.
+func kustomizeRequest(request OperationRequest, operation Operation) OperationRequest {
+	if namespace := operation.Properties[KustomizeNamespaceKey]; namespace != "" {
+		request.Namespace = namespace
+	}
+	return request
+}
+
+// kustomization returns the overlay of a kustomization set in the operation properties, nil if none is set.
+func (o *Operation) kustomization() *types.Kustomization {
+	overlay := &types.Kustomization{
+		Namespace:    o.Properties[KustomizeNamespaceKey],
+		CommonLabels: map[string]string{},
+	}
+
+	keys := make([]string, 0, len(o.Properties))
+	for key := range o.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch {
+		case strings.HasPrefix(key, KustomizeImagePrefix):
+			overlay.Images = append(overlay.Images, kustomizeImage(strings.TrimPrefix(key, KustomizeImagePrefix), o.Properties[key]))
+		case strings.HasPrefix(key, KustomizeLabelPrefix):
+			overlay.CommonLabels[strings.TrimPrefix(key, KustomizeLabelPrefix)] = o.Properties[key]
+		}
+	}
+
+	if overlay.Namespace == "" && len(overlay.Images) == 0 && len(overlay.CommonLabels) == 0 {
+		return nil
+	}
+	return overlay
+}
+
+// kustomizeImage returns the replacement of the image with the given name by the reference,
+// "[newName][:newTag]" or "[newName][@digest]", e.g. "docker.io/istio/proxyv2:1.7.3", or ":1.7.3".
+func kustomizeImage(name, reference string) types.Image {
+	image := types.Image{Name: name}
+	if i := strings.Index(reference, "@"); i >= 0 {
+		image.Digest = reference[i+1:]
+		reference = reference[:i]
+	} else if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		image.NewTag = reference[i+1:]
+		reference = reference[:i]
+	}
+	image.NewName = reference
+	return image
+}
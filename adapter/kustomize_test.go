@@ -0,0 +1,78 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestKustomizeImage(t *testing.T) {
+	tests := []struct {
+		reference string
+		want      types.Image
+	}{
+		{
+			reference: "docker.io/istio/proxyv2:1.7.3",
+			want:      types.Image{Name: "istio/proxyv2", NewName: "docker.io/istio/proxyv2", NewTag: "1.7.3"},
+		},
+		{
+			reference: ":1.7.3",
+			want:      types.Image{Name: "istio/proxyv2", NewTag: "1.7.3"},
+		},
+		{
+			reference: "docker.io/istio/proxyv2",
+			want:      types.Image{Name: "istio/proxyv2", NewName: "docker.io/istio/proxyv2"},
+		},
+		{
+			reference: "localhost:5000/istio/proxyv2",
+			want:      types.Image{Name: "istio/proxyv2", NewName: "localhost:5000/istio/proxyv2"},
+		},
+		{
+			reference: "localhost:5000/istio/proxyv2:1.7.3",
+			want:      types.Image{Name: "istio/proxyv2", NewName: "localhost:5000/istio/proxyv2", NewTag: "1.7.3"},
+		},
+		{
+			reference: "docker.io/istio/proxyv2@sha256:abc",
+			want:      types.Image{Name: "istio/proxyv2", NewName: "docker.io/istio/proxyv2", Digest: "sha256:abc"},
+		},
+		{
+			reference: "@sha256:abc",
+			want:      types.Image{Name: "istio/proxyv2", Digest: "sha256:abc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			if got := kustomizeImage("istio/proxyv2", tt.reference); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestKustomizeRequest(t *testing.T) {
+	request := OperationRequest{OperationName: "install", Namespace: "default"}
+	if got := kustomizeRequest(request, Operation{}); got.Namespace != "default" {
+		t.Errorf("expected namespace default, got %s", got.Namespace)
+	}
+	operation := Operation{Properties: map[string]string{KustomizeNamespaceKey: "istio-system"}}
+	if got := kustomizeRequest(request, operation); got.Namespace != "istio-system" || got.OperationName != "install" {
+		t.Errorf("expected request %+v in namespace istio-system, got %+v", request, got)
+	}
+	if request.Namespace != "default" {
+		t.Errorf("expected the request to be unchanged, got namespace %s", request.Namespace)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultLeaseDuration is the default duration of a Lease, after which it can be taken over if it has not been renewed.
+	DefaultLeaseDuration = 30 * time.Second
+	// DefaultLeaseNamespace is the default namespace of Leases.
+	DefaultLeaseNamespace = "default"
+	// leasePrefix is the prefix of the names of Leases, followed by the target namespace.
+	leasePrefix = "meshery-adapter-lock-"
+	// clusterLeaseName is the name of the Lease of cluster wide operations. It does not start with leasePrefix,
+	// such that it is distinct from the names of the Leases of all namespaces.
+	clusterLeaseName = "meshery-adapter-cluster-lock"
+	// lockPollInterval is the interval in which a held Lease is checked while waiting for it.
+	lockPollInterval = time.Second
+)
+
+// LockManager serializes operations per instance and target namespace. Within the adapter, operations are serialized
+// with in-memory locks. Optionally, multiple replicas of the adapter coordinate using a Lease of the coordination.k8s.io/v1 API
+// per target namespace in the cluster of the instance. It is safe for concurrent use.
+type LockManager struct {
+	// Wait queues operations until the namespace is unlocked, instead of failing them with ErrOperationInProgress.
+	Wait bool
+	// Lease enables the coordination with other replicas using Leases.
+	Lease bool
+	// LeaseNamespace is the namespace of Leases, defaults to DefaultLeaseNamespace.
+	LeaseNamespace string
+	// LeaseDuration defaults to DefaultLeaseDuration. Leases are renewed while they are held.
+	LeaseDuration time.Duration
+	// Identity identifies the adapter replica holding a Lease, defaults to the host name with a random suffix.
+	Identity string
+
+	mu    sync.Mutex
+	locks map[string]*namespaceLock
+	once  sync.Once
+}
+
+// namespaceLock is the in-memory lock of a target namespace, holding the operation ID of its holder.
+type namespaceLock struct {
+	sem    chan struct{}
+	holder string
+}
+
+// heldLock marks a context of an operation holding the lock of a target namespace.
+type heldLock struct {
+	manager *LockManager
+	key     string
+	next    *heldLock
+}
+
+type heldLockKey struct{}
+
+// holds returns true if the lock with the given key is held by the operation of the context.
+func (m *LockManager) holds(ctx context.Context, key string) bool {
+	held, _ := ctx.Value(heldLockKey{}).(*heldLock)
+	for ; held != nil; held = held.next {
+		if held.manager == m && held.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Lock locks the target namespace of the operation in the instance, and returns the context of the operation holding the lock,
+// and a function unlocking it. The context is canceled when the Lease of the lock is lost, or when the lock is unlocked.
+// Locking a namespace already locked by the operation of the context has no effect.
+// ErrOperationInProgress is returned if the namespace is locked by another operation, and Wait is not set.
+func (m *LockManager) Lock(ctx context.Context, instance *Instance, namespace, operationID string) (context.Context, func(), error) {
+	m.once.Do(m.defaults)
+
+	key := instance.Name + "/" + namespace
+	if m.holds(ctx, key) {
+		return ctx, func() {}, nil
+	}
+	lock := m.namespaceLock(key)
+	select {
+	case lock.sem <- struct{}{}:
+	default:
+		if !m.Wait {
+			return nil, nil, ErrOperationInProgress(namespace, m.holder(lock))
+		}
+		select {
+		case lock.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+	m.setHolder(lock, operationID)
+	held, _ := ctx.Value(heldLockKey{}).(*heldLock)
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, heldLockKey{}, &heldLock{manager: m, key: key, next: held}))
+	unlockLocal := func() {
+		cancel()
+		m.setHolder(lock, "")
+		<-lock.sem
+	}
+
+	if !m.Lease {
+		return ctx, unlockLocal, nil
+	}
+	releaseLease, err := m.acquireLease(ctx, instance, namespace, cancel)
+	if err != nil {
+		unlockLocal()
+		return nil, nil, err
+	}
+	return ctx, func() {
+		releaseLease()
+		unlockLocal()
+	}, nil
+}
+
+func (m *LockManager) defaults() {
+	if m.LeaseNamespace == "" {
+		m.LeaseNamespace = DefaultLeaseNamespace
+	}
+	if m.LeaseDuration <= 0 {
+		m.LeaseDuration = DefaultLeaseDuration
+	}
+	if m.Identity == "" {
+		hostname, _ := os.Hostname()
+		m.Identity = fmt.Sprintf("%s_%s", hostname, uuid.New().String())
+	}
+}
+
+func (m *LockManager) namespaceLock(key string) *namespaceLock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locks == nil {
+		m.locks = make(map[string]*namespaceLock)
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &namespaceLock{sem: make(chan struct{}, 1)}
+		m.locks[key] = lock
+	}
+	return lock
+}
+
+func (m *LockManager) holder(lock *namespaceLock) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return lock.holder
+}
+
+func (m *LockManager) setHolder(lock *namespaceLock, operationID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lock.holder = operationID
+}
+
+// acquireLease acquires the Lease of the namespace, and renews it until the returned function is called, which deletes it.
+// If the Lease is lost, lost is called.
+func (m *LockManager) acquireLease(ctx context.Context, instance *Instance, namespace string, lost func()) (func(), error) {
+	name := leaseName(namespace)
+	for {
+		lease, holder, err := m.tryAcquireLease(ctx, instance, name)
+		if err != nil {
+			err = gherrors.Wrapf(err, "unable to acquire lease %s/%s", m.LeaseNamespace, name)
+			logrus.Error(err)
+			return nil, err
+		}
+		if lease != nil {
+			renewCtx, stopRenewal := context.WithCancel(context.Background())
+			renewed := make(chan *coordinationv1.Lease, 1)
+			go func() {
+				renewed <- m.renewLease(renewCtx, instance, lease, lost)
+			}()
+			return func() {
+				stopRenewal()
+				if lease := <-renewed; lease != nil {
+					m.deleteLease(instance, lease)
+				}
+			}, nil
+		}
+		if !m.Wait {
+			return nil, ErrOperationInProgress(namespace, holder)
+		}
+		select {
+		case <-time.After(lockPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// tryAcquireLease creates the Lease, or takes it over if it has expired. If it is held by another replica,
+// the lease is nil and the identity of the holder is returned.
+func (m *LockManager) tryAcquireLease(ctx context.Context, instance *Instance, name string) (*coordinationv1.Lease, string, error) {
+	leases := instance.KubeClient.CoordinationV1().Leases(m.LeaseNamespace)
+	now := metav1.NewMicroTime(time.Now())
+	seconds := int32(m.LeaseDuration / time.Second)
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       &m.Identity,
+		LeaseDurationSeconds: &seconds,
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+
+	current, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease, err := leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: m.LeaseNamespace},
+			Spec:       spec,
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return nil, "", nil
+		}
+		return lease, "", err
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if leaseHolder(current) != "" && leaseHolder(current) != m.Identity && !leaseExpired(current) {
+		return nil, leaseHolder(current), nil
+	}
+	current.Spec = spec
+	lease, err := leases.Update(ctx, current, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return nil, "", nil
+	}
+	return lease, "", err
+}
+
+// renewLease renews the Lease every third of its duration until the context is done, and returns the last renewed Lease.
+// Failed renewals are retried until the Lease expires. If the Lease has been taken over, or has expired,
+// lost is called, and nil is returned.
+func (m *LockManager) renewLease(ctx context.Context, instance *Instance, lease *coordinationv1.Lease, lost func()) *coordinationv1.Lease {
+	leases := instance.KubeClient.CoordinationV1().Leases(m.LeaseNamespace)
+	ticker := time.NewTicker(m.LeaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return lease
+		case <-ticker.C:
+			now := metav1.NewMicroTime(time.Now())
+			renewal := lease.DeepCopy()
+			renewal.Spec.RenewTime = &now
+			renewed, err := leases.Update(ctx, renewal, metav1.UpdateOptions{})
+			if err == nil {
+				lease = renewed
+				continue
+			}
+			if ctx.Err() != nil {
+				return lease
+			}
+			if apierrors.IsConflict(err) || apierrors.IsNotFound(err) || leaseExpired(lease) {
+				logrus.Errorf("lost lease %s/%s, canceling the operation: %v", lease.Namespace, lease.Name, err)
+				lost()
+				return nil
+			}
+			logrus.Errorf("unable to renew lease %s/%s: %v", lease.Namespace, lease.Name, err)
+		}
+	}
+}
+
+// deleteLease deletes the Lease, unless it has been changed since it has been renewed, i.e. has been taken over by another replica.
+func (m *LockManager) deleteLease(instance *Instance, lease *coordinationv1.Lease) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.LeaseDuration)
+	defer cancel()
+	err := instance.KubeClient.CoordinationV1().Leases(m.LeaseNamespace).Delete(ctx, lease.Name, leaseDeleteOptions(lease))
+	if apierrors.IsConflict(err) {
+		logrus.Warnf("lease %s/%s has been taken over, it is not released", m.LeaseNamespace, lease.Name)
+		return
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		logrus.Errorf("unable to release lease %s/%s: %v", m.LeaseNamespace, lease.Name, err)
+	}
+}
+
+// leaseDeleteOptions returns the options deleting the Lease only if it is unchanged.
+func leaseDeleteOptions(lease *coordinationv1.Lease) metav1.DeleteOptions {
+	uid, resourceVersion := lease.UID, lease.ResourceVersion
+	return metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid, ResourceVersion: &resourceVersion},
+	}
+}
+
+func leaseHolder(lease *coordinationv1.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func leaseExpired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return time.Now().After(expiry)
+}
+
+// leaseName returns the name of the Lease of the target namespace, or clusterLeaseName for cluster wide operations.
+func leaseName(namespace string) string {
+	if namespace == "" {
+		return clusterLeaseName
+	}
+	name := strings.ToLower(leasePrefix + namespace)
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		name = name[:validation.DNS1123SubdomainMaxLength]
+	}
+	return name
+}
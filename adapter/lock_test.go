@@ -0,0 +1,255 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLeaseExpired(t *testing.T) {
+	lease := func(renewed time.Duration, seconds int32) *coordinationv1.Lease {
+		renewTime := metav1.NewMicroTime(time.Now().Add(-renewed))
+		return &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{RenewTime: &renewTime, LeaseDurationSeconds: &seconds}}
+	}
+	seconds := int32(30)
+
+	tests := []struct {
+		name  string
+		lease *coordinationv1.Lease
+		want  bool
+	}{
+		{name: "renewed", lease: lease(10*time.Second, 30), want: false},
+		{name: "expired", lease: lease(31*time.Second, 30), want: true},
+		{name: "never renewed", lease: &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{LeaseDurationSeconds: &seconds}}, want: true},
+		{name: "without duration", lease: &coordinationv1.Lease{Spec: coordinationv1.LeaseSpec{RenewTime: lease(0, 0).Spec.RenewTime}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leaseExpired(tt.lease); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLockManager(t *testing.T) {
+	locks := &LockManager{}
+	instance := &Instance{Name: "cluster"}
+
+	ctx, unlock, err := locks.Lock(context.Background(), instance, "istio-system", "op1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := locks.Lock(context.Background(), instance, "istio-system", "op2"); err == nil {
+		t.Error("expected the namespace to be locked by another operation")
+	}
+	if _, unlockOther, err := locks.Lock(context.Background(), instance, "default", "op2"); err != nil {
+		t.Errorf("expected another namespace not to be locked, got %v", err)
+	} else {
+		unlockOther()
+	}
+
+	reentrantCtx, unlockReentrant, err := locks.Lock(ctx, instance, "istio-system", "op1")
+	if err != nil {
+		t.Fatalf("expected the operation holding the lock to lock the namespace again, got %v", err)
+	}
+	unlockReentrant()
+	if reentrantCtx.Err() != nil {
+		t.Error("expected the nested unlock not to cancel the operation")
+	}
+	if _, _, err := locks.Lock(context.Background(), instance, "istio-system", "op2"); err == nil {
+		t.Error("expected the nested unlock not to unlock the namespace")
+	}
+
+	unlock()
+	if ctx.Err() == nil {
+		t.Error("expected the context of the operation to be canceled when it is unlocked")
+	}
+	_, unlock, err = locks.Lock(context.Background(), instance, "istio-system", "op2")
+	if err != nil {
+		t.Fatalf("expected the namespace to be unlocked, got %v", err)
+	}
+	unlock()
+}
+
+func TestLockManagerWait(t *testing.T) {
+	locks := &LockManager{Wait: true}
+	instance := &Instance{Name: "cluster"}
+
+	_, unlock, err := locks.Lock(context.Background(), instance, "istio-system", "op1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := locks.Lock(ctx, instance, "istio-system", "op2"); err != context.DeadlineExceeded {
+		t.Errorf("expected to wait for the lock until the deadline, got %v", err)
+	}
+
+	acquired := make(chan error)
+	go func() {
+		_, unlock, err := locks.Lock(context.Background(), instance, "istio-system", "op3")
+		if err == nil {
+			unlock()
+		}
+		acquired <- err
+	}()
+	unlock()
+	if err := <-acquired; err != nil {
+		t.Errorf("expected the waiting operation to acquire the lock, got %v", err)
+	}
+}
+
+func TestLeaseName(t *testing.T) {
+	if got := leaseName("istio-system"); got != "meshery-adapter-lock-istio-system" {
+		t.Errorf("expected the lease name of the namespace, got %s", got)
+	}
+	if leaseName("") == leaseName("cluster") {
+		t.Errorf("expected the lease name of cluster wide operations to differ from the one of namespace cluster, both are %s", leaseName(""))
+	}
+}
+
+// testLease returns a Lease of the namespace held by the holder, renewed the given time ago.
+func testLease(namespace, holder string, renewed time.Duration) *coordinationv1.Lease {
+	renewTime := metav1.NewMicroTime(time.Now().Add(-renewed))
+	seconds := int32(30)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: leaseName(namespace), Namespace: DefaultLeaseNamespace},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder, LeaseDurationSeconds: &seconds, RenewTime: &renewTime},
+	}
+}
+
+func getLease(t *testing.T, client *fake.Clientset, namespace string) (*coordinationv1.Lease, error) {
+	t.Helper()
+	return client.CoordinationV1().Leases(DefaultLeaseNamespace).Get(context.Background(), leaseName(namespace), metav1.GetOptions{})
+}
+
+func TestLockManagerLease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	locks := &LockManager{Lease: true, Identity: "replica-1"}
+	instance := &Instance{Name: "cluster", KubeClient: client}
+
+	_, unlock, err := locks.Lock(context.Background(), instance, "istio-system", "op1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lease, err := getLease(t, client, "istio-system")
+	if err != nil {
+		t.Fatalf("expected the lease to be created, got %v", err)
+	}
+	if holder := leaseHolder(lease); holder != "replica-1" {
+		t.Errorf("expected the lease to be held by replica-1, got %q", holder)
+	}
+
+	unlock()
+	if _, err := getLease(t, client, "istio-system"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the lease to be deleted when it is unlocked, got %v", err)
+	}
+}
+
+func TestLockManagerLeaseHeld(t *testing.T) {
+	client := fake.NewSimpleClientset(testLease("istio-system", "replica-2", 0))
+	locks := &LockManager{Lease: true, Identity: "replica-1"}
+	instance := &Instance{Name: "cluster", KubeClient: client}
+
+	_, _, err := locks.Lock(context.Background(), instance, "istio-system", "op1")
+	if err == nil || !strings.Contains(err.Error(), "replica-2") {
+		t.Fatalf("expected the namespace to be locked by replica-2, got %v", err)
+	}
+	lease, err := getLease(t, client, "istio-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holder := leaseHolder(lease); holder != "replica-2" {
+		t.Errorf("expected the lease to be held by replica-2, got %q", holder)
+	}
+	if _, _, err := locks.Lock(context.Background(), instance, "istio-system", "op1"); err == nil || !strings.Contains(err.Error(), "replica-2") {
+		t.Errorf("expected the local lock to be released after the failure, got %v", err)
+	}
+}
+
+func TestLockManagerLeaseExpired(t *testing.T) {
+	client := fake.NewSimpleClientset(testLease("istio-system", "replica-2", time.Minute))
+	locks := &LockManager{Lease: true, Identity: "replica-1"}
+	instance := &Instance{Name: "cluster", KubeClient: client}
+
+	_, unlock, err := locks.Lock(context.Background(), instance, "istio-system", "op1")
+	if err != nil {
+		t.Fatalf("expected the expired lease to be taken over, got %v", err)
+	}
+	defer unlock()
+	lease, err := getLease(t, client, "istio-system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holder := leaseHolder(lease); holder != "replica-1" || leaseExpired(lease) {
+		t.Errorf("expected the lease to be renewed by replica-1, got holder %q", holder)
+	}
+}
+
+func TestLockManagerLeaseLost(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("update", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, leaseName("istio-system"), nil)
+	})
+	locks := &LockManager{Lease: true, Identity: "replica-1", LeaseDuration: 3 * time.Second}
+	instance := &Instance{Name: "cluster", KubeClient: client}
+
+	ctx, unlock, err := locks.Lock(context.Background(), instance, "istio-system", "op1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer unlock()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the operation to be canceled when the lease is lost")
+	}
+}
+
+func TestLockManagerLeaseRelease(t *testing.T) {
+	lease := testLease("istio-system", "replica-1", 0)
+	lease.UID, lease.ResourceVersion = "uid-1", "2"
+	options := leaseDeleteOptions(lease)
+	if options.Preconditions == nil || *options.Preconditions.UID != "uid-1" || *options.Preconditions.ResourceVersion != "2" {
+		t.Errorf("expected the deletion to be conditional on the UID and resource version of the lease, got %+v", options.Preconditions)
+	}
+
+	// the precondition fails if the lease has been taken over by another replica since it has been renewed
+	client := fake.NewSimpleClientset(testLease("istio-system", "replica-2", 0))
+	client.PrependReactor("delete", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, leaseName("istio-system"), nil)
+	})
+	locks := &LockManager{Lease: true, Identity: "replica-1", LeaseNamespace: DefaultLeaseNamespace, LeaseDuration: DefaultLeaseDuration}
+	locks.deleteLease(&Instance{Name: "cluster", KubeClient: client}, lease)
+	taken, err := getLease(t, client, "istio-system")
+	if err != nil {
+		t.Fatalf("expected the lease taken over not to be released, got %v", err)
+	}
+	if holder := leaseHolder(taken); holder != "replica-2" {
+		t.Errorf("expected the lease to be held by replica-2, got %q", holder)
+	}
+}
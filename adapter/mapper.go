@@ -0,0 +1,65 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"errors"
+
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+)
+
+// newDiscoveryClient creates a client for the discovery API of the cluster. Discovery information
+// is cached in memory and only retrieved when it is first requested.
+func newDiscoveryClient(config *rest.Config) (discovery.CachedDiscoveryInterface, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return memory.NewMemCacheClient(discoveryClient), nil
+}
+
+// resourceMapping resolves the GroupVersionResource and the scope of the given object.
+// If the kind is unknown, the discovery cache is refreshed once, as the kind might have been
+// added to the cluster after the cache was populated, e.g. by a CRD created earlier in the same manifest.
+func (i *Instance) resourceMapping(data *unstructured.Unstructured) (*meta.RESTMapping, error) {
+	if i.RESTMapper == nil {
+		return nil, errors.New("mesh client has not been created")
+	}
+	gvk := data.GroupVersionKind()
+	mapping, err := i.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		logrus.Debugf("no mapping found for %s, refreshing discovery information", gvk.String())
+		i.RESTMapper.Reset()
+		mapping, err = i.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		err = gherrors.Wrapf(err, "unable to map kind %s to a resource", gvk.String())
+		logrus.Error(err)
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// isNamespaced returns true if the resource of the mapping is namespace scoped.
+func isNamespaced(mapping *meta.RESTMapping) bool {
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
@@ -14,18 +14,144 @@
 
 package adapter
 
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+const (
+	// DescriptionKey is the operation property holding the description of the operation.
+	DescriptionKey = "description"
+	// ReadinessTimeoutKey is the operation property holding the maximum time to wait for the applied resources
+	// to become ready, e.g. "5m". The operation does not wait for readiness if it is not set.
+	ReadinessTimeoutKey = "readiness-timeout"
+	// RollbackKey is the operation property enabling the rollback of the changes made by the operation
+	// if it fails, e.g. "true". Changes are not rolled back if it is not set.
+	RollbackKey = "rollback"
+	// PruneKey is the operation property enabling the deletion of the resources of the operation by their ownership labels,
+	// instead of by the resources in its manifest, e.g. "true".
+	PruneKey = "prune"
+	// TimeoutKey is the operation property holding the maximum duration of the operation, e.g. "10m".
+	// The operation is canceled when it is exceeded. The duration is not limited if it is not set.
+	TimeoutKey = "timeout"
+	// HelmReleaseKey is the operation property holding the release name of a Helm chart, defaults to the chart name.
+	HelmReleaseKey = "helm-release"
+	// HelmUpgradeKey is the operation property marking the operation as upgrade of the release of a Helm chart, e.g. "true".
+	// Chart templates are rendered as upgrade, and resources are applied with server-side apply, such that existing
+	// resources are updated regardless of the ApplyMode of the handler.
+	HelmUpgradeKey = "helm-upgrade"
+	// HelmValuesPrefix is the prefix of operation properties holding values of a Helm chart, in the syntax of
+	// 'helm --set', e.g. "helm-values.global.hub" with value "docker.io/istio".
+	HelmValuesPrefix = "helm-values."
+	// KustomizeNamespaceKey is the operation property holding the namespace set on all resources of a kustomization,
+	// it takes precedence over the namespace of the request.
+	KustomizeNamespaceKey = "kustomize-namespace"
+	// KustomizeImagePrefix is the prefix of operation properties replacing an image of a kustomization, e.g.
+	// "kustomize-image.istio/proxyv2" with value "docker.io/istio/proxyv2:1.7.3", or ":1.7.3" to only replace the tag.
+	KustomizeImagePrefix = "kustomize-image."
+	// KustomizeLabelPrefix is the prefix of operation properties holding common labels of a kustomization, e.g.
+	// "kustomize-label.env" with value "staging".
+	KustomizeLabelPrefix = "kustomize-label."
+)
+
 type Operation struct {
 	Type       int32             `json:"type,string,omitempty"`
 	Properties map[string]string `json:"properties,omitempty"`
+	// Parameters are the input parameters of the operation, see ValidateParameters.
+	Parameters []Parameter `json:"parameters,omitempty"`
 }
 
 type Operations map[string]*Operation
 
+// ListOperations returns the configured and the registered operations, see RegisterOperation.
 func (h *BaseHandler) ListOperations() (Operations, error) {
-	operations := make(Operations)
-	err := h.Config.Operations(&operations)
+	operations := h.operations.list()
+	if h.Config == nil {
+		return operations, nil
+	}
+	configured := make(Operations)
+	err := h.Config.Operations(&configured)
 	if err != nil {
 		return nil, err
 	}
+	for name, operation := range configured {
+		operations[name] = operation
+	}
 	return operations, nil
 }
+
+// readinessTimeout returns the time to wait for the applied resources to become ready, zero if not set.
+func (o *Operation) readinessTimeout() (time.Duration, error) {
+	timeout, ok := o.Properties[ReadinessTimeoutKey]
+	if !ok || timeout == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(timeout)
+}
+
+// rollback returns true if the changes made by the operation have to be rolled back if it fails.
+func (o *Operation) rollback() (bool, error) {
+	rollback, ok := o.Properties[RollbackKey]
+	if !ok || rollback == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(rollback)
+}
+
+// prune returns true if the resources of the operation are deleted by their ownership labels.
+func (o *Operation) prune() (bool, error) {
+	prune, ok := o.Properties[PruneKey]
+	if !ok || prune == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(prune)
+}
+
+// helmUpgrade returns true if the operation upgrades the release of a Helm chart.
+func (o *Operation) helmUpgrade() (bool, error) {
+	upgrade, ok := o.Properties[HelmUpgradeKey]
+	if !ok || upgrade == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(upgrade)
+}
+
+// withTimeout returns a context canceled when the timeout of the operation is exceeded, or the given context if no timeout is set.
+func (o *Operation) withTimeout(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	timeout, ok := o.Properties[TimeoutKey]
+	if !ok || timeout == "" {
+		return ctx, func() {}, nil
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	return ctx, cancel, nil
+}
+
+// helmValues returns the Helm chart values set in the operation properties.
+func (o *Operation) helmValues() (map[string]interface{}, error) {
+	keys := make([]string, 0)
+	for key := range o.Properties {
+		if strings.HasPrefix(key, HelmValuesPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	values := map[string]interface{}{}
+	for _, key := range keys {
+		value := fmt.Sprintf("%s=%s", strings.TrimPrefix(key, HelmValuesPrefix), o.Properties[key])
+		if err := strvals.ParseInto(value, values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// crdEstablishedTimeout is the maximum time to wait for a CRD to become established before its custom resources are created.
+const crdEstablishedTimeout = time.Minute
+
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// applyOrder lists the kinds in the order they are applied, such that the dependencies of a resource are created first.
+// Kinds not listed, in particular custom resources, are applied last.
+var applyOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"PriorityClass",
+	"PodSecurityPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"StorageClass",
+	"ServiceAccount",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Secret",
+	"ConfigMap",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"HorizontalPodAutoscaler",
+	"PodDisruptionBudget",
+	"NetworkPolicy",
+	"Ingress",
+	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+var applyRank = func() map[string]int {
+	rank := make(map[string]int, len(applyOrder))
+	for i, kind := range applyOrder {
+		rank[kind] = i
+	}
+	return rank
+}()
+
+func kindRank(data *unstructured.Unstructured) int {
+	if rank, ok := applyRank[data.GetKind()]; ok {
+		return rank
+	}
+	return len(applyOrder)
+}
+
+// sortByKind sorts the objects in the order they have to be applied, or in reverse order when they are deleted.
+// Objects of the same kind keep their relative order.
+func sortByKind(objects []*unstructured.Unstructured, isDelete bool) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return kindRank(objects[i]) < kindRank(objects[j])
+	})
+	if isDelete {
+		for i, j := 0, len(objects)-1; i < j; i, j = i+1, j-1 {
+			objects[i], objects[j] = objects[j], objects[i]
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSortByKind(t *testing.T) {
+	object := func(kind, name string) *unstructured.Unstructured {
+		data := &unstructured.Unstructured{}
+		data.SetKind(kind)
+		data.SetName(name)
+		return data
+	}
+	names := func(objects []*unstructured.Unstructured) []string {
+		result := make([]string, 0, len(objects))
+		for _, data := range objects {
+			result = append(result, data.GetName())
+		}
+		return result
+	}
+	manifest := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			object("VirtualService", "routes"),
+			object("Deployment", "first"),
+			object("Service", "svc"),
+			object("CustomResourceDefinition", "crd"),
+			object("Deployment", "second"),
+			object("Namespace", "ns"),
+			object("Gateway", "gateway"),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		isDelete bool
+		want     []string
+	}{
+		{
+			name: "apply",
+			want: []string{"ns", "crd", "svc", "first", "second", "routes", "gateway"},
+		},
+		{
+			name:     "delete",
+			isDelete: true,
+			want:     []string{"gateway", "routes", "second", "first", "svc", "crd", "ns"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := manifest()
+			sortByKind(objects, tt.isDelete)
+			if got := names(objects); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
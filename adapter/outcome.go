@@ -0,0 +1,65 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	gherrors "github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ObjectOutcome is the outcome of applying a single object of a manifest.
+type ObjectOutcome string
+
+const (
+	// ObjectCreated objects have been created, existing objects are replaced by custom operations.
+	ObjectCreated ObjectOutcome = "created"
+	// ObjectApplied objects have been written with server-side apply, they might have been created or changed.
+	ObjectApplied ObjectOutcome = "applied"
+	// ObjectUnchanged objects already existed, and have been left as they are.
+	ObjectUnchanged ObjectOutcome = "unchanged"
+	// ObjectDeleted objects have been deleted.
+	ObjectDeleted ObjectOutcome = "deleted"
+	// ObjectSkippedMissing objects to be deleted did not exist.
+	ObjectSkippedMissing ObjectOutcome = "skipped-missing"
+	// ObjectFailed objects could not be applied, the operation stopped at them.
+	ObjectFailed ObjectOutcome = "failed"
+)
+
+// ObjectResult is the outcome of applying a single object of a manifest.
+type ObjectResult struct {
+	Outcome   ObjectOutcome `json:"outcome,omitempty"`
+	Kind      string        `json:"kind,omitempty"`
+	Namespace string        `json:"namespace,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	// Error is the error of failed objects.
+	Error string `json:"error,omitempty"`
+}
+
+// namespaceFallback returns true if an API call failed for the namespace of the object has to be made again
+// without namespace, which is the case if the resource has not been found in the namespace.
+func namespaceFallback(data *unstructured.Unstructured, err error) bool {
+	return data.GetNamespace() != "" && apierrors.IsNotFound(gherrors.Cause(err))
+}
+
+// fallbackError returns the error of an API call made again without namespace. If the resource has not been found either,
+// the error of the call for the namespace is kept, as it is the more specific one.
+func fallbackError(namespacedErr, err error) error {
+	if err != nil && apierrors.IsNotFound(gherrors.Cause(err)) {
+		return namespacedErr
+	}
+	return err
+}
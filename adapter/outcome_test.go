@@ -0,0 +1,127 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const configMapManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: mesh-config
+  namespace: demo
+`
+
+func TestApplyConfigChangeOutcomes(t *testing.T) {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("v1")
+	existing.SetKind("ConfigMap")
+	existing.SetNamespace("demo")
+	existing.SetName("mesh-config")
+
+	tests := []struct {
+		name     string
+		isDelete bool
+		objects  []runtime.Object
+		reactor  k8stesting.ReactionFunc
+		want     ObjectResult
+		wantErr  bool
+	}{
+		{
+			name: "created",
+			want: ObjectResult{Outcome: ObjectCreated},
+		},
+		{
+			name:    "already exists on create",
+			objects: []runtime.Object{existing},
+			want:    ObjectResult{Outcome: ObjectUnchanged},
+		},
+		{
+			name: "already exists without namespace on create",
+			reactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.GetNamespace() != "" {
+					return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, action.GetNamespace())
+				}
+				return true, nil, apierrors.NewAlreadyExists(configMaps, "mesh-config")
+			},
+			want: ObjectResult{Outcome: ObjectUnchanged},
+		},
+		{
+			name:     "deleted",
+			isDelete: true,
+			objects:  []runtime.Object{existing},
+			want:     ObjectResult{Outcome: ObjectDeleted},
+		},
+		{
+			name:     "not found on delete",
+			isDelete: true,
+			want:     ObjectResult{Outcome: ObjectSkippedMissing},
+		},
+		{
+			name: "failed",
+			reactor: func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(configMaps, "mesh-config", errors.New("denied"))
+			},
+			want:    ObjectResult{Outcome: ObjectFailed},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), tt.objects...)
+			if tt.reactor != nil {
+				dynamicClient.PrependReactor("*", "configmaps", tt.reactor)
+			}
+			instance := fakeInstance(&metav1.APIResourceList{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+			})
+			instance.DynamicKubeClient = dynamicClient
+			opts := applyOptions{
+				instance:  instance,
+				isDelete:  tt.isDelete,
+				result:    &OperationResult{},
+				resources: &resourceList{},
+			}
+
+			err := (&BaseHandler{}).applyConfigChange(context.Background(), configMapManifest, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyConfigChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := tt.want
+			want.Kind, want.Namespace, want.Name = "ConfigMap", "demo", "mesh-config"
+			if err != nil {
+				want.Error = err.Error()
+			}
+			if got := opts.result.Objects(); !reflect.DeepEqual(got, []ObjectResult{want}) {
+				t.Errorf("Objects() = %+v, want %+v", got, []ObjectResult{want})
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// The labels, and annotations, stamped on every resource applied by an operation.
+// The annotations are always set, the labels only if the value is a valid label value.
+const (
+	AdapterLabel     = "adapter.meshery.io/name"
+	OperationLabel   = "adapter.meshery.io/operation"
+	OperationIDLabel = "adapter.meshery.io/operation-id"
+)
+
+// ownership identifies the adapter and operation that applied a resource.
+type ownership struct {
+	adapter     string
+	operation   string
+	operationID string
+}
+
+func (o ownership) values() map[string]string {
+	return map[string]string{
+		AdapterLabel:     o.adapter,
+		OperationLabel:   o.operation,
+		OperationIDLabel: o.operationID,
+	}
+}
+
+// stamp sets the ownership labels and annotations on the resource.
+func (o ownership) stamp(data *unstructured.Unstructured) {
+	objLabels := data.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	annotations := data.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range o.values() {
+		if value == "" {
+			continue
+		}
+		annotations[key] = value
+		if len(validation.IsValidLabelValue(value)) == 0 {
+			objLabels[key] = value
+		}
+	}
+	data.SetLabels(objLabels)
+	data.SetAnnotations(annotations)
+}
+
+// selector selects the resources applied by any run of the operation of the adapter.
+func (o ownership) selector() (string, error) {
+	set := labels.Set{
+		AdapterLabel:   o.adapter,
+		OperationLabel: o.operation,
+	}
+	for key, value := range set {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return "", fmt.Errorf("unable to select resources by %s=%s: %s", key, value, strings.Join(errs, ", "))
+		}
+	}
+	return labels.SelectorFromSet(set).String(), nil
+}
+
+func (h *BaseHandler) ownership(request OperationRequest) ownership {
+	return ownership{
+		adapter:     h.GetName(),
+		operation:   request.OperationName,
+		operationID: request.OperationID,
+	}
+}
+
+// PruneOperation deletes all resources that have been applied by the operation of the request, identified by their
+// ownership labels. In contrast to a delete operation, the manifest of the operation does not need to be rendered,
+// hence resources are deleted even if the manifest changed since they were applied. Namespaced resources are only
+// deleted in the namespace of the request, or in all namespaces if it is empty.
+func (h *BaseHandler) PruneOperation(ctx context.Context, request OperationRequest) error {
+	instance, err := h.requestInstance(request)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+	ctx = withRetrier(ctx, h.retrier(request.OperationID))
+	if err := h.pruneResources(ctx, instance, h.ownership(request), request.Namespace, request.OperationID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+	return nil
+}
+
+// pruneResources deletes the resources applied by the operation of the owner. Namespaced resources are only deleted
+// in the given namespace, or in all namespaces if it is empty.
+func (h *BaseHandler) pruneResources(ctx context.Context, instance *Instance, owner ownership, namespace, operationID string) error {
+	if instance.DynamicKubeClient == nil || instance.discoveryClient == nil {
+		return errors.New("mesh client has not been created")
+	}
+	selector, err := owner.selector()
+	if err != nil {
+		return err
+	}
+
+	resourceLists, err := discovery.ServerPreferredResources(instance.discoveryClient)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return gherrors.Wrap(err, "unable to discover the resources of the cluster")
+		}
+		logrus.Warn(gherrors.Wrap(err, "unable to discover all resources of the cluster, pruning the discovered resources"))
+	}
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}}, resourceLists)
+
+	objects := []*unstructured.Unstructured{}
+	resources := map[types.UID]schema.GroupVersionResource{}
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resourceList.APIResources {
+			res := gv.WithResource(resource.Name)
+			var client dynamic.ResourceInterface = instance.DynamicKubeClient.Resource(res)
+			if resource.Namespaced && namespace != "" {
+				client = instance.DynamicKubeClient.Resource(res).Namespace(namespace)
+			}
+			var list *unstructured.UnstructuredList
+			err := retry(ctx, fmt.Sprintf("list %s", res.String()), func() (err error) {
+				list, err = client.List(ctx, metav1.ListOptions{LabelSelector: selector})
+				return err
+			})
+			if err != nil {
+				err = gherrors.Wrapf(err, "unable to list the resources of type: %s", res.String())
+				logrus.Warn(err)
+				continue
+			}
+			for i := range list.Items {
+				item := &list.Items[i]
+				// the same resource can be served by multiple groups
+				if _, ok := resources[item.GetUID()]; ok {
+					continue
+				}
+				resources[item.GetUID()] = res
+				objects = append(objects, item)
+			}
+		}
+	}
+	sortByKind(objects, true)
+
+	for _, data := range objects {
+		if err := instance.deleteResource(ctx, resources[data.GetUID()], data); err != nil {
+			if apierrors.IsNotFound(gherrors.Cause(err)) {
+				continue
+			}
+			return err
+		}
+		h.streamInfo(operationID, fmt.Sprintf("Pruned %s %s", data.GetKind(), data.GetName()), "")
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParameterType is the type of the value of an operation parameter.
+type ParameterType string
+
+const (
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeInteger ParameterType = "integer"
+	ParameterTypeNumber  ParameterType = "number"
+	ParameterTypeBoolean ParameterType = "boolean"
+)
+
+// Parameter declares an input parameter of an operation. Values are passed as strings, and have to be parsable as the type of the parameter.
+type Parameter struct {
+	Name string `json:"name"`
+	// Type is the type of the value, defaults to ParameterTypeString.
+	Type ParameterType `json:"type,omitempty"`
+	// Default is the value used if no value is given.
+	Default string `json:"default,omitempty"`
+	// Required parameters without default have to be given a value.
+	Required bool `json:"required,omitempty"`
+	// Enum are the allowed values, any value is allowed if empty.
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ParameterError is returned if the values of parameters do not conform to the parameters declared by an operation.
+type ParameterError struct {
+	Errors []string `json:"errors,omitempty"`
+}
+
+func (e *ParameterError) Error() string {
+	return fmt.Sprintf("invalid parameters: %s", strings.Join(e.Errors, "; "))
+}
+
+// validate returns an error if the value is not of the type of the parameter, or not one of its enum values.
+func (p Parameter) validate(value string) error {
+	var err error
+	switch p.Type {
+	case "", ParameterTypeString:
+	case ParameterTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case ParameterTypeNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case ParameterTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("parameter %s has unknown type %s", p.Name, p.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("value %q of parameter %s is not of type %s", value, p.Name, p.Type)
+	}
+	if len(p.Enum) > 0 {
+		for _, allowed := range p.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %q of parameter %s is not one of %s", value, p.Name, strings.Join(p.Enum, ", "))
+	}
+	return nil
+}
+
+// ValidateParameters validates the values against the parameters declared by the operation, and returns them
+// completed with the defaults of parameters without value. Values of undeclared parameters are rejected.
+func (o *Operation) ValidateParameters(values map[string]string) (map[string]string, error) {
+	declared := make(map[string]Parameter, len(o.Parameters))
+	for _, parameter := range o.Parameters {
+		declared[parameter.Name] = parameter
+	}
+
+	errs := []string{}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := declared[name]; !ok {
+			errs = append(errs, fmt.Sprintf("unknown parameter %s", name))
+		}
+	}
+
+	result := make(map[string]string, len(o.Parameters))
+	for _, parameter := range o.Parameters {
+		value, ok := values[parameter.Name]
+		if !ok {
+			if parameter.Default == "" {
+				if parameter.Required {
+					errs = append(errs, fmt.Sprintf("missing required parameter %s", parameter.Name))
+				}
+				continue
+			}
+			value = parameter.Default
+		}
+		if err := parameter.validate(value); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		result[parameter.Name] = value
+	}
+
+	if len(errs) > 0 {
+		return nil, &ParameterError{Errors: errs}
+	}
+	return result, nil
+}
+
+// parameterDefaults returns the defaults of the parameters of the operation.
+func (o *Operation) parameterDefaults() map[string]string {
+	defaults := make(map[string]string, len(o.Parameters))
+	for _, parameter := range o.Parameters {
+		if parameter.Default != "" {
+			defaults[parameter.Name] = parameter.Default
+		}
+	}
+	return defaults
+}
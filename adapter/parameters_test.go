@@ -0,0 +1,100 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateParameters(t *testing.T) {
+	operation := Operation{Parameters: []Parameter{
+		{Name: "version", Required: true},
+		{Name: "replicas", Type: ParameterTypeInteger, Default: "1"},
+		{Name: "ratio", Type: ParameterTypeNumber},
+		{Name: "mtls", Type: ParameterTypeBoolean},
+		{Name: "profile", Enum: []string{"demo", "minimal"}, Default: "demo"},
+	}}
+
+	tests := []struct {
+		name   string
+		values map[string]string
+		want   map[string]string
+		errs   []string
+	}{
+		{
+			name:   "defaults",
+			values: map[string]string{"version": "1.7"},
+			want:   map[string]string{"version": "1.7", "replicas": "1", "profile": "demo"},
+		},
+		{
+			name:   "values override defaults",
+			values: map[string]string{"version": "1.7", "replicas": "3", "ratio": "0.5", "mtls": "true", "profile": "minimal"},
+			want:   map[string]string{"version": "1.7", "replicas": "3", "ratio": "0.5", "mtls": "true", "profile": "minimal"},
+		},
+		{
+			name:   "missing required",
+			values: map[string]string{},
+			errs:   []string{"missing required parameter version"},
+		},
+		{
+			name:   "wrong types",
+			values: map[string]string{"version": "1.7", "replicas": "three", "ratio": "half", "mtls": "yes"},
+			errs: []string{
+				`value "three" of parameter replicas is not of type integer`,
+				`value "half" of parameter ratio is not of type number`,
+				`value "yes" of parameter mtls is not of type boolean`,
+			},
+		},
+		{
+			name:   "not in enum",
+			values: map[string]string{"version": "1.7", "profile": "default"},
+			errs:   []string{`value "default" of parameter profile is not one of demo, minimal`},
+		},
+		{
+			name:   "unknown",
+			values: map[string]string{"version": "1.7", "zone": "a", "region": "b"},
+			errs:   []string{"unknown parameter region", "unknown parameter zone"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := operation.ValidateParameters(tt.values)
+			if tt.errs != nil {
+				parameterErr, ok := err.(*ParameterError)
+				if !ok {
+					t.Fatalf("expected a ParameterError, got %v", err)
+				}
+				if !reflect.DeepEqual(parameterErr.Errors, tt.errs) {
+					t.Errorf("expected errors %q, got %q", tt.errs, parameterErr.Errors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateParametersUnknownType(t *testing.T) {
+	operation := Operation{Parameters: []Parameter{{Name: "size", Type: "bytes"}}}
+	if _, err := operation.ValidateParameters(map[string]string{"size": "1k"}); err == nil {
+		t.Error("expected an error for a parameter of unknown type")
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Permission is an action on a resource required by an operation.
+type Permission struct {
+	Verb      string `json:"verb,omitempty"`
+	Group     string `json:"group,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource = p.Group + "/" + resource
+	}
+	if p.Name != "" {
+		resource += " " + p.Name
+	}
+	if p.Namespace != "" {
+		return fmt.Sprintf("%s %s in namespace %s", p.Verb, resource, p.Namespace)
+	}
+	return fmt.Sprintf("%s %s", p.Verb, resource)
+}
+
+// MissingPermissionsError is returned by the pre-flight check of an operation if permissions required by the operation are missing.
+type MissingPermissionsError struct {
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+func (e *MissingPermissionsError) Error() string {
+	permissions := make([]string, 0, len(e.Permissions))
+	for _, p := range e.Permissions {
+		permissions = append(permissions, p.String())
+	}
+	return fmt.Sprintf("missing permissions: %s", strings.Join(permissions, ", "))
+}
+
+// requiredVerbs returns the verbs required on every resource of the manifest.
+func (h *BaseHandler) requiredVerbs(opts applyOptions, waitForReadiness bool) []string {
+	if opts.isDelete {
+		return []string{"delete"}
+	}
+	verbs := []string{"create"}
+	if opts.applyMode == ApplyModeServerSide {
+		verbs = append(verbs, "patch")
+	}
+	if opts.isCustomOp {
+		verbs = append(verbs, "delete")
+	}
+	if opts.dryRun || waitForReadiness || opts.journal != nil {
+		verbs = append(verbs, "get")
+	}
+	if opts.journal != nil {
+		verbs = append(verbs, "update", "delete")
+	}
+	return verbs
+}
+
+// checkPermissions verifies with SelfSubjectAccessReviews that all permissions required to apply the manifest are granted.
+// Missing permissions are recorded in the operation result, and streamed as an error event of the operation.
+func (h *BaseHandler) checkPermissions(ctx context.Context, yamlFileContents string, opts applyOptions, waitForReadiness bool) error {
+	objects, err := h.decodeManifest(yamlFileContents, opts.isDelete)
+	if err != nil {
+		return err
+	}
+
+	permissions, err := h.requiredPermissions(objects, opts, waitForReadiness)
+	if err != nil {
+		return err
+	}
+	missing := []Permission{}
+	for _, permission := range permissions {
+		allowed, err := opts.instance.hasPermission(ctx, permission)
+		if err != nil {
+			err = gherrors.Wrapf(err, "unable to check the permission to %s", permission.String())
+			logrus.Error(err)
+			return err
+		}
+		if !allowed {
+			missing = append(missing, permission)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	permissionsErr := &MissingPermissionsError{Permissions: missing}
+	logrus.Error(permissionsErr)
+	if opts.result != nil {
+		opts.result.addMissingPermissions(missing)
+	}
+	if h.Channel != nil {
+		h.StreamErr(&Event{
+			Operationid: opts.operationID,
+			Summary:     "Pre-flight check failed, permissions are missing",
+			Details:     permissionsErr.Error(),
+		}, ErrMissingPermissions(permissionsErr))
+	}
+	return permissionsErr
+}
+
+// requiredPermissions returns the permissions required to apply the objects, without duplicates. Custom resources whose
+// kind is not known to the cluster yet are mapped with the CRDs in the objects. Objects of unknown kinds without CRD are skipped.
+func (h *BaseHandler) requiredPermissions(objects []*unstructured.Unstructured, opts applyOptions, waitForReadiness bool) ([]Permission, error) {
+	var crdMappings map[schema.GroupKind]*meta.RESTMapping
+	verbs := h.requiredVerbs(opts, waitForReadiness)
+	checked := map[Permission]bool{}
+	permissions := []Permission{}
+	for _, data := range objects {
+		mapping, err := opts.instance.resourceMapping(data)
+		if err != nil {
+			if !meta.IsNoMatchError(gherrors.Cause(err)) {
+				return nil, err
+			}
+			if opts.isDelete {
+				continue
+			}
+			if crdMappings == nil {
+				crdMappings = manifestCRDMappings(objects)
+			}
+			gvk := data.GroupVersionKind()
+			crdMapping, ok := crdMappings[gvk.GroupKind()]
+			if !ok {
+				logrus.Warnf("skipping the permission check of resource of unknown type: %s and name: %s", gvk.String(), data.GetName())
+				continue
+			}
+			mapping = &meta.RESTMapping{
+				Resource:         crdMapping.Resource.GroupResource().WithVersion(gvk.Version),
+				GroupVersionKind: gvk,
+				Scope:            crdMapping.Scope,
+			}
+		}
+		namespace := ""
+		if isNamespaced(mapping) {
+			namespace = data.GetNamespace()
+			if opts.namespace != "" {
+				namespace = opts.namespace
+			}
+		}
+
+		resourceVerbs := verbs
+		if opts.isDelete && mapping.Resource.Resource == "deployments" { // deployments are scaled down before they are deleted
+			resourceVerbs = append([]string{"get", "update"}, verbs...)
+		}
+		if !opts.isDelete && !opts.dryRun && data.GroupVersionKind().GroupKind() == crdGroupKind { // until they are established
+			resourceVerbs = append([]string{"get"}, resourceVerbs...)
+		}
+		for _, verb := range resourceVerbs {
+			permission := Permission{
+				Verb:      verb,
+				Group:     mapping.Resource.Group,
+				Resource:  mapping.Resource.Resource,
+				Namespace: namespace,
+			}
+			if verb != "create" {
+				permission.Name = data.GetName()
+			}
+			if checked[permission] {
+				continue
+			}
+			checked[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions, nil
+}
+
+// manifestCRDMappings returns the mappings of the kinds defined by the CRDs in the objects, by their group and kind.
+// The version of the mappings is not set.
+func manifestCRDMappings(objects []*unstructured.Unstructured) map[schema.GroupKind]*meta.RESTMapping {
+	mappings := map[schema.GroupKind]*meta.RESTMapping{}
+	for _, data := range objects {
+		if data.GroupVersionKind().GroupKind() != crdGroupKind {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(data.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(data.Object, "spec", "names", "kind")
+		plural, _, _ := unstructured.NestedString(data.Object, "spec", "names", "plural")
+		scope, _, _ := unstructured.NestedString(data.Object, "spec", "scope")
+		if kind == "" || plural == "" {
+			continue
+		}
+		mapping := &meta.RESTMapping{
+			Resource:         schema.GroupVersionResource{Group: group, Resource: plural},
+			GroupVersionKind: schema.GroupVersionKind{Group: group, Kind: kind},
+			Scope:            meta.RESTScopeRoot,
+		}
+		if scope == "Namespaced" {
+			mapping.Scope = meta.RESTScopeNamespace
+		}
+		mappings[mapping.GroupVersionKind.GroupKind()] = mapping
+	}
+	return mappings
+}
+
+func (i *Instance) hasPermission(ctx context.Context, permission Permission) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:      permission.Verb,
+				Group:     permission.Group,
+				Resource:  permission.Resource,
+				Namespace: permission.Namespace,
+				Name:      permission.Name,
+			},
+		},
+	}
+	result, err := i.KubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/restmapper"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const crdManifest = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: my-widget
+  namespace: demo
+---
+apiVersion: unknown.example.com/v1
+kind: Gadget
+metadata:
+  name: my-gadget
+`
+
+func fakeInstance(resources ...*metav1.APIResourceList) *Instance {
+	discoveryClient := memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{Resources: resources}})
+	return &Instance{
+		Name:            "fake",
+		RESTMapper:      restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient),
+		discoveryClient: discoveryClient,
+	}
+}
+
+func TestRequiredPermissionsOfCustomResources(t *testing.T) {
+	instance := fakeInstance(&metav1.APIResourceList{
+		GroupVersion: "apiextensions.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "customresourcedefinitions", Kind: "CustomResourceDefinition"}},
+	})
+	h := &BaseHandler{}
+	objects, err := h.decodeManifest(crdManifest, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	permissions, err := h.requiredPermissions(objects, applyOptions{instance: instance}, false)
+	if err != nil {
+		t.Fatalf("requiredPermissions() error = %v", err)
+	}
+	want := []Permission{
+		{Verb: "get", Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions", Name: "widgets.example.com"},
+		{Verb: "create", Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"},
+		{Verb: "create", Group: "example.com", Resource: "widgets", Namespace: "demo"},
+	}
+	if !reflect.DeepEqual(permissions, want) {
+		t.Errorf("requiredPermissions() = %v, want %v", permissions, want)
+	}
+
+	permissions, err = h.requiredPermissions(objects, applyOptions{instance: instance, namespace: "other"}, false)
+	if err != nil {
+		t.Fatalf("requiredPermissions() error = %v", err)
+	}
+	if got := permissions[len(permissions)-1]; got.Resource != "widgets" || got.Namespace != "other" {
+		t.Errorf("requiredPermissions() of the custom resource = %v, want widgets in namespace other", got)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const readinessPollInterval = 2 * time.Second
+
+// appliedResource is a resource targeted by an apply.
+type appliedResource struct {
+	resource schema.GroupVersionResource
+	object   *unstructured.Unstructured
+}
+
+// resourceList records the resources targeted by an apply. It is safe for concurrent use.
+type resourceList struct {
+	mu        sync.Mutex
+	resources []appliedResource
+}
+
+func (l *resourceList) add(res schema.GroupVersionResource, data *unstructured.Unstructured) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resources = append(l.resources, appliedResource{resource: res, object: data})
+}
+
+func (l *resourceList) list() []appliedResource {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]appliedResource{}, l.resources...)
+}
+
+// readinessCheck returns true if the resource is ready, and an error if it will never become ready.
+type readinessCheck func(data *unstructured.Unstructured) (bool, error)
+
+// readinessChecks are the readiness gates by kind, resources of other kinds are considered ready once they are created.
+var readinessChecks = map[schema.GroupKind]readinessCheck{
+	{Group: "apps", Kind: "Deployment"}:                               deploymentReady,
+	{Group: "apps", Kind: "StatefulSet"}:                              statefulSetReady,
+	{Group: "apps", Kind: "DaemonSet"}:                                daemonSetReady,
+	{Group: "batch", Kind: "Job"}:                                     jobReady,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: crdReady,
+	{Group: "", Kind: "Pod"}:                                          podReady,
+}
+
+// waitForReadiness waits until all resources with a readiness gate are ready, or the timeout expires.
+// Progress is streamed as events of the operation.
+func (h *BaseHandler) waitForReadiness(ctx context.Context, instance *Instance, resources []appliedResource, timeout time.Duration, operationID string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, r := range resources {
+		check, ok := readinessChecks[r.object.GroupVersionKind().GroupKind()]
+		if !ok {
+			continue
+		}
+		kind, name := r.object.GetKind(), r.object.GetName()
+		h.streamInfo(operationID, fmt.Sprintf("Waiting for %s %s to become ready", kind, name), "")
+
+		var checkErr error
+		err := wait.PollImmediateUntil(readinessPollInterval, func() (bool, error) {
+			current, err := instance.DynamicKubeClient.Resource(r.resource).Namespace(r.object.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				logrus.Debugf("unable to retrieve %s %s while waiting for readiness: %v", kind, name, err)
+				return false, nil
+			}
+			ready, err := check(current)
+			checkErr = err
+			return ready, err
+		}, ctx.Done())
+		if checkErr != nil {
+			return fmt.Errorf("%s %s will not become ready: %v", kind, name, checkErr)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s did not become ready within %s: %v", kind, name, timeout, err)
+		}
+		h.streamInfo(operationID, fmt.Sprintf("%s %s is ready", kind, name), "")
+	}
+	return nil
+}
+
+func deploymentReady(data *unstructured.Unstructured) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data.UnstructuredContent(), deployment); err != nil {
+		return false, err
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.Replicas == replicas &&
+		status.AvailableReplicas == replicas, nil
+}
+
+func statefulSetReady(data *unstructured.Unstructured) (bool, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data.UnstructuredContent(), statefulSet); err != nil {
+		return false, err
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	status := statefulSet.Status
+	return status.ObservedGeneration >= statefulSet.Generation &&
+		status.ReadyReplicas == replicas &&
+		status.CurrentRevision == status.UpdateRevision, nil
+}
+
+func daemonSetReady(data *unstructured.Unstructured) (bool, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data.UnstructuredContent(), daemonSet); err != nil {
+		return false, err
+	}
+	status := daemonSet.Status
+	return status.ObservedGeneration >= daemonSet.Generation &&
+		status.UpdatedNumberScheduled == status.DesiredNumberScheduled &&
+		status.NumberAvailable == status.DesiredNumberScheduled, nil
+}
+
+func jobReady(data *unstructured.Unstructured) (bool, error) {
+	job := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data.UnstructuredContent(), job); err != nil {
+		return false, err
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("job failed: %s", condition.Message)
+		}
+	}
+	return false, nil
+}
+
+func crdReady(data *unstructured.Unstructured) (bool, error) {
+	conditions, _, err := unstructured.NestedSlice(data.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Established" && condition["status"] == string(v1.ConditionTrue) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func podReady(data *unstructured.Unstructured) (bool, error) {
+	pod := &v1.Pod{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data.UnstructuredContent(), pod); err != nil {
+		return false, err
+	}
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		return true, nil
+	case v1.PodFailed:
+		return false, fmt.Errorf("pod failed: %s", pod.Status.Message)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
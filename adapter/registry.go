@@ -0,0 +1,142 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// OperationFunc applies an operation registered with RegisterOperation. The operation is the configuration of the
+// operation, see ListOperations. The returned message is used as summary of the event emitted on success.
+type OperationFunc func(ctx context.Context, request OperationRequest, operation Operation) (string, error)
+
+// registeredOperation is an operation registered with its handler function.
+type registeredOperation struct {
+	operation Operation
+	apply     OperationFunc
+}
+
+// operationRegistry holds the registered operations by name. It is safe for concurrent use.
+type operationRegistry struct {
+	mu         sync.RWMutex
+	operations map[string]registeredOperation
+}
+
+func (r *operationRegistry) add(name string, operation Operation, apply OperationFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.operations == nil {
+		r.operations = make(map[string]registeredOperation)
+	}
+	r.operations[name] = registeredOperation{operation: operation, apply: apply}
+}
+
+func (r *operationRegistry) get(name string) (registeredOperation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	operation, ok := r.operations[name]
+	return operation, ok
+}
+
+// list returns a copy of the registered operations.
+func (r *operationRegistry) list() Operations {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	operations := make(Operations, len(r.operations))
+	for name, registered := range r.operations {
+		operation := registered.operation
+		operations[name] = &operation
+	}
+	return operations
+}
+
+// RegisterOperation registers the handler function of the operation with the given name, replacing a function registered before.
+// The operation is listed by ListOperations, unless an operation with the same name is configured, which takes precedence.
+// Registered operations are applied by ApplyOperation.
+func (h *BaseHandler) RegisterOperation(name string, operation Operation, apply OperationFunc) {
+	h.operations.add(name, operation, apply)
+}
+
+// ApplyOperation applies a registered operation. The parameters of the request are validated against the parameters
+// declared by the operation, see Operation.ValidateParameters, ErrInvalidParameters is returned if they are invalid.
+// The namespace of the request is created before the handler function is called, in dry runs its creation is previewed,
+// and the outcome is emitted as an event. With Locks, the target namespace is locked for the whole operation, unless
+// it is a dry run. The operation is canceled if its timeout, see TimeoutKey, is exceeded. ErrOpInvalid is returned
+// for operations that have not been registered.
+func (h *BaseHandler) ApplyOperation(ctx context.Context, request OperationRequest) error {
+	registered, ok := h.operations.get(request.OperationName)
+	if !ok {
+		logrus.Errorf("operation %s has not been registered", request.OperationName)
+		return ErrOpInvalid
+	}
+	operations, err := h.ListOperations()
+	if err != nil {
+		return err
+	}
+	operation := registered.operation
+	if configured, ok := operations[request.OperationName]; ok && configured != nil {
+		operation = *configured
+	}
+	if _, err := operation.ValidateParameters(request.Parameters); err != nil {
+		err = ErrInvalidParameters(err)
+		h.streamErr(request.OperationID, fmt.Sprintf("Invalid parameters of operation %s", request.OperationName), err)
+		return err
+	}
+	ctx, cancel, err := operation.withTimeout(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if h.Locks != nil && !request.DryRun {
+		instance, err := h.requestInstance(request)
+		if err != nil {
+			err = ErrApplyOperation(request.OperationName, err)
+			h.streamErr(request.OperationID, fmt.Sprintf("Error applying operation %s", request.OperationName), err)
+			return err
+		}
+		lockCtx, unlock, err := h.Locks.Lock(ctx, instance, request.Namespace, request.OperationID)
+		if err != nil {
+			err = ErrApplyOperation(request.OperationName, err)
+			h.streamErr(request.OperationID, fmt.Sprintf("Error locking namespace %s", request.Namespace), err)
+			return err
+		}
+		defer unlock()
+		ctx = lockCtx
+	}
+	request = request.WithContext(ctx)
+
+	if err := h.CreateOperationNamespace(request); err != nil {
+		err = ErrCreateNamespace(err)
+		h.streamErr(request.OperationID, fmt.Sprintf("Error creating namespace %s", request.Namespace), err)
+		return err
+	}
+
+	message, err := registered.apply(ctx, request, operation)
+	if err != nil {
+		err = ErrApplyOperation(request.OperationName, err)
+		h.streamErr(request.OperationID, fmt.Sprintf("Error applying operation %s", request.OperationName), err)
+		return err
+	}
+	if message == "" {
+		message = fmt.Sprintf("Operation %s applied successfully", request.OperationName)
+	}
+	h.streamInfo(request.OperationID, message, "")
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"testing"
+)
+
+func TestApplyOperationValidatesParameters(t *testing.T) {
+	h := &BaseHandler{}
+	applied := 0
+	h.RegisterOperation("install", Operation{Parameters: []Parameter{{Name: "version", Type: ParameterTypeString, Required: true}}},
+		func(ctx context.Context, request OperationRequest, operation Operation) (string, error) {
+			applied++
+			return "", nil
+		})
+
+	request := OperationRequest{OperationName: "install", OperationID: "op1", Namespace: "default"}
+	if err := h.ApplyOperation(context.Background(), request); err == nil {
+		t.Error("expected the missing required parameter to be rejected")
+	}
+	if applied != 0 {
+		t.Errorf("expected the operation not to be applied with invalid parameters, applied %d times", applied)
+	}
+
+	request.Parameters = map[string]string{"version": "1.7.3"}
+	if err := h.ApplyOperation(context.Background(), request); err != nil {
+		t.Errorf("expected the operation to be applied, got %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("expected the operation to be applied once, applied %d times", applied)
+	}
+}
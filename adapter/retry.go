@@ -0,0 +1,153 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// RetryPolicy defines how Kubernetes API calls failing with transient errors are retried: throttling (429), server errors (5xx),
+// conflicts and timeouts.
+type RetryPolicy struct {
+	// Retries is the maximum number of retries of a call, zero disables retries.
+	Retries int
+	// Backoff is the delay before the first retry. It is multiplied by Factor for every further retry, up to MaxBackoff.
+	// Backoff defaults to the Backoff of DefaultRetryPolicy if it is not positive, Factor to 1 if it is less than 1.
+	Backoff    time.Duration
+	Factor     float64
+	MaxBackoff time.Duration
+	// Jitter adds a random delay of up to Jitter times the delay, such that concurrent calls do not retry at the same time.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the retry policy used if none is set, see BaseHandler.RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	Retries:    5,
+	Backoff:    500 * time.Millisecond,
+	Factor:     2,
+	MaxBackoff: 30 * time.Second,
+	Jitter:     0.5,
+}
+
+// defaults returns the policy with defaults for the backoff and factor, such that the delay of retries does not decrease.
+func (p RetryPolicy) defaults() RetryPolicy {
+	if p.Backoff <= 0 {
+		p.Backoff = DefaultRetryPolicy.Backoff
+	}
+	if p.Factor < 1 {
+		p.Factor = 1
+	}
+	return p
+}
+
+// delay returns the delay before the given retry, starting at 1.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	delay := float64(p.Backoff)
+	for i := 1; i < retry; i++ {
+		delay *= p.Factor
+		if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+			delay = float64(p.MaxBackoff)
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		delay += rand.Float64() * p.Jitter * delay
+	}
+	return time.Duration(delay)
+}
+
+// isRetryable returns true if the error of an API call is transient, i.e. the call might succeed if it is retried.
+func isRetryable(err error) bool {
+	err = gherrors.Cause(err)
+	if apierrors.IsTooManyRequests(err) || apierrors.IsConflict(err) ||
+		apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Code >= 500 {
+		return true
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// retrier retries API calls of an operation, and reports the retries.
+type retrier struct {
+	policy RetryPolicy
+	notify func(description string, retry int, delay time.Duration, err error)
+}
+
+type retrierKey struct{}
+
+// withRetrier returns a context whose API calls are retried by the retrier.
+func withRetrier(ctx context.Context, r *retrier) context.Context {
+	return context.WithValue(ctx, retrierKey{}, r)
+}
+
+// retrier returns the retrier of the operation, streaming retries as warning events of the operation.
+func (h *BaseHandler) retrier(operationID string) *retrier {
+	policy := DefaultRetryPolicy
+	if h.RetryPolicy != nil {
+		policy = h.RetryPolicy.defaults()
+	}
+	return &retrier{
+		policy: policy,
+		notify: func(description string, retry int, delay time.Duration, err error) {
+			h.streamWarn(operationID,
+				fmt.Sprintf("Retrying to %s", description),
+				fmt.Sprintf("Retry %d of %d in %s after transient error: %s", retry, policy.Retries, delay.Round(time.Millisecond), err.Error()))
+		},
+	}
+}
+
+// retry calls the function, and retries it on transient errors with the retrier of the context.
+// The function is called once if the context has no retrier.
+func retry(ctx context.Context, description string, call func() error) error {
+	r, ok := ctx.Value(retrierKey{}).(*retrier)
+	if !ok {
+		return call()
+	}
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= r.policy.Retries || !isRetryable(err) {
+			return err
+		}
+
+		delay := r.policy.delay(attempt + 1)
+		if seconds, ok := apierrors.SuggestsClientDelay(gherrors.Cause(err)); ok && time.Duration(seconds)*time.Second > delay {
+			delay = time.Duration(seconds) * time.Second
+		}
+		logrus.Warnf("unable to %s, retrying in %s: %v", description, delay, err)
+		if r.notify != nil {
+			r.notify(description, attempt+1, delay, err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gherrors "github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond, Factor: 2, MaxBackoff: time.Second}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{retry: 1, want: 100 * time.Millisecond},
+		{retry: 2, want: 200 * time.Millisecond},
+		{retry: 3, want: 400 * time.Millisecond},
+		{retry: 4, want: 800 * time.Millisecond},
+		{retry: 5, want: time.Second},
+		{retry: 10, want: time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.delay(tt.retry); got != tt.want {
+			t.Errorf("retry %d: expected %s, got %s", tt.retry, tt.want, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.delay(2); got < 200*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("expected a delay between 200ms and 300ms with jitter, got %s", got)
+		}
+	}
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   RetryPolicy
+	}{
+		{name: "zero backoff", policy: RetryPolicy{Retries: 3, Factor: 2}, want: RetryPolicy{Retries: 3, Backoff: DefaultRetryPolicy.Backoff, Factor: 2}},
+		{name: "negative backoff", policy: RetryPolicy{Backoff: -time.Second, Factor: 2}, want: RetryPolicy{Backoff: DefaultRetryPolicy.Backoff, Factor: 2}},
+		{name: "factor below 1", policy: RetryPolicy{Backoff: time.Second, Factor: 0.5}, want: RetryPolicy{Backoff: time.Second, Factor: 1}},
+		{name: "valid", policy: RetryPolicy{Backoff: time.Second, Factor: 3}, want: RetryPolicy{Backoff: time.Second, Factor: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.defaults(); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	h := &BaseHandler{RetryPolicy: &RetryPolicy{Retries: 3}}
+	if delay := h.retrier("op1").policy.delay(3); delay < DefaultRetryPolicy.Backoff {
+		t.Errorf("expected the retrier to apply the default backoff, got a delay of %s", delay)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	resource := schema.GroupResource{Resource: "deployments"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "too many requests", err: apierrors.NewTooManyRequests("throttled", 1), want: true},
+		{name: "internal error", err: apierrors.NewInternalError(errors.New("etcd")), want: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("unavailable"), want: true},
+		{name: "server timeout", err: apierrors.NewServerTimeout(resource, "create", 1), want: true},
+		{name: "conflict", err: apierrors.NewConflict(resource, "istiod", errors.New("modified")), want: true},
+		{name: "wrapped", err: gherrors.Wrap(apierrors.NewTooManyRequests("throttled", 1), "unable to create"), want: true},
+		{name: "not found", err: apierrors.NewNotFound(resource, "istiod"), want: false},
+		{name: "forbidden", err: apierrors.NewForbidden(resource, "istiod", errors.New("denied")), want: false},
+		{name: "invalid", err: apierrors.NewBadRequest("invalid"), want: false},
+		{name: "field manager conflict", err: &ConflictError{Kind: "Deployment", Name: "istiod"}, want: false},
+		{name: "other", err: errors.New("failed"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := withRetrier(context.Background(), &retrier{policy: RetryPolicy{Retries: 3, Backoff: time.Millisecond, Factor: 1}})
+
+	calls := 0
+	err := retry(ctx, "create", func() error {
+		calls++
+		if calls < 3 {
+			return apierrors.NewTooManyRequests("throttled", 0)
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected success after 3 calls, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = retry(ctx, "create", func() error {
+		calls++
+		return apierrors.NewServiceUnavailable("unavailable")
+	})
+	if !apierrors.IsServiceUnavailable(err) || calls != 4 {
+		t.Errorf("expected the error after 4 calls, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	_ = retry(context.Background(), "create", func() error {
+		calls++
+		return apierrors.NewServiceUnavailable("unavailable")
+	})
+	if calls != 1 {
+		t.Errorf("expected no retries without retrier, got %d calls", calls)
+	}
+}
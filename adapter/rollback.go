@@ -0,0 +1,157 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// rollbackTimeout is the maximum duration of the rollback of an operation that has been canceled or has timed out.
+const rollbackTimeout = 5 * time.Minute
+
+// journalEntry is a resource created or changed by an operation.
+type journalEntry struct {
+	resource schema.GroupVersionResource
+	object   *unstructured.Unstructured
+	// previous is the state of the resource before it was changed, nil if it was created.
+	previous *unstructured.Unstructured
+}
+
+// journal records the resources created or changed by an operation, so that the changes can be rolled back.
+// A nil journal does not record anything. It is safe for concurrent use.
+type journal struct {
+	mu      sync.Mutex
+	entries []journalEntry
+}
+
+func (j *journal) record(res schema.GroupVersionResource, data, previous *unstructured.Unstructured) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, journalEntry{resource: res, object: data, previous: previous})
+}
+
+func (j *journal) list() []journalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]journalEntry{}, j.entries...)
+}
+
+// RollbackFailure is a change of an operation that could not be rolled back, leaving its resource in a partial state.
+type RollbackFailure struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Action is the failed step of the rollback, "delete" for created resources, "restore" for changed ones.
+	Action string `json:"action,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RollbackError lists the changes that could not be rolled back, such that the resources can be cleaned up.
+type RollbackError struct {
+	Failures []RollbackFailure `json:"failures,omitempty"`
+}
+
+func (e *RollbackError) Error() string {
+	failures := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s %s %s/%s (%s)", f.Action, f.Kind, f.Namespace, f.Name, f.Error))
+	}
+	return fmt.Sprintf("unable to roll back %d changes: %s", len(e.Failures), strings.Join(failures, ", "))
+}
+
+// rollback reverts the changes recorded in the journal in reverse order. Created resources are deleted,
+// changed resources are restored to their previous state. Every step is streamed as an event of the operation,
+// a failing step does not stop the rollback of the remaining changes. The failed steps are returned as ErrRollback
+// of a RollbackError, and streamed as an error event.
+func (h *BaseHandler) rollback(ctx context.Context, instance *Instance, j *journal, operationID string) error {
+	if j == nil {
+		return nil
+	}
+	if ctx.Err() != nil { // the operation has been canceled or timed out, the rollback gets its own deadline
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), rollbackTimeout)
+		defer cancel()
+	}
+	rollbackErr := &RollbackError{}
+	entries := j.list()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		kind, name := entry.object.GetKind(), entry.object.GetName()
+
+		var err error
+		var action, summary string
+		if entry.previous == nil {
+			err = instance.deleteResource(ctx, entry.resource, entry.object)
+			action, summary = "delete", fmt.Sprintf("Rolled back: deleted %s %s", kind, name)
+		} else {
+			err = instance.restoreResource(ctx, entry.resource, entry.previous)
+			action, summary = "restore", fmt.Sprintf("Rolled back: restored %s %s", kind, name)
+		}
+		if err != nil {
+			logrus.Errorf("unable to roll back %s %s: %v", kind, name, err)
+			rollbackErr.Failures = append(rollbackErr.Failures, RollbackFailure{
+				Kind:      kind,
+				Namespace: entry.object.GetNamespace(),
+				Name:      name,
+				Action:    action,
+				Error:     err.Error(),
+			})
+			continue
+		}
+		h.streamInfo(operationID, summary, "")
+	}
+
+	if len(rollbackErr.Failures) == 0 {
+		return nil
+	}
+	err := ErrRollback(rollbackErr)
+	h.streamErr(operationID, fmt.Sprintf("Rollback failed, %d resources are left in a partial state", len(rollbackErr.Failures)), err)
+	return err
+}
+
+// restoreResource replaces the resource by its previous state, or recreates it if it does not exist anymore.
+func (i *Instance) restoreResource(ctx context.Context, res schema.GroupVersionResource, previous *unstructured.Unstructured) error {
+	client := i.DynamicKubeClient.Resource(res).Namespace(previous.GetNamespace())
+	restored := previous.DeepCopy()
+	restored.SetUID("") // the resource might have been recreated
+	unstructured.RemoveNestedField(restored.Object, "metadata", "managedFields")
+
+	current, err := client.Get(ctx, previous.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		restored.SetResourceVersion("")
+		_, err = client.Create(ctx, restored, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	restored.SetResourceVersion(current.GetResourceVersion())
+	_, err = client.Update(ctx, restored, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestRollbackListsFailures(t *testing.T) {
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	configMap := func(name string) *unstructured.Unstructured {
+		data := &unstructured.Unstructured{}
+		data.SetAPIVersion("v1")
+		data.SetKind("ConfigMap")
+		data.SetNamespace("istio-system")
+		data.SetName(name)
+		return data
+	}
+	created, vanished := configMap("created"), configMap("vanished")
+	instance := &Instance{DynamicKubeClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), created)}
+
+	j := &journal{}
+	j.record(configMaps, created, nil)
+	j.record(configMaps, vanished, nil)
+
+	h := &BaseHandler{}
+	err := h.rollback(context.Background(), instance, j, "op1")
+	if err == nil {
+		t.Fatal("expected the failed rollback of the vanished resource to be reported")
+	}
+	if !strings.Contains(err.Error(), "delete ConfigMap istio-system/vanished") || strings.Contains(err.Error(), "istio-system/created") {
+		t.Errorf("expected only the vanished resource to be listed, got %v", err)
+	}
+
+	if err := h.rollback(context.Background(), instance, &journal{}, "op1"); err != nil {
+		t.Errorf("expected an empty journal to be rolled back, got %v", err)
+	}
+}
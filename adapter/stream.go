@@ -36,3 +36,41 @@ func (h *BaseHandler) StreamInfo(e *Event) {
 	e.EType = 0
 	*h.Channel <- e
 }
+
+// streamInfo sends an info event for the operation, if an event channel has been set.
+func (h *BaseHandler) streamInfo(operationID, summary, details string) {
+	if h.Channel == nil {
+		return
+	}
+	h.StreamInfo(&Event{
+		Operationid: operationID,
+		Summary:     summary,
+		Details:     details,
+	})
+}
+
+// streamErr sends an error event for the operation, if an event channel has been set.
+func (h *BaseHandler) streamErr(operationID, summary string, err error) {
+	if h.Channel == nil {
+		return
+	}
+	h.StreamErr(&Event{
+		Operationid: operationID,
+		Summary:     summary,
+		Details:     err.Error(),
+	}, err)
+}
+
+// streamWarn sends a warning event for the operation, if an event channel has been set.
+func (h *BaseHandler) streamWarn(operationID, summary, details string) {
+	if h.Channel == nil {
+		return
+	}
+	h.Log.Info("Sending warning event")
+	*h.Channel <- &Event{
+		Operationid: operationID,
+		EType:       1,
+		Summary:     summary,
+		Details:     details,
+	}
+}
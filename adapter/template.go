@@ -0,0 +1,71 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/ghodss/yaml"
+)
+
+// templateFuncs returns the functions available in templates: the sprig library, and
+// toYaml, fromYaml, required and include, like in Helm charts.
+func templateFuncs(tmpl *template.Template) template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	funcs["toYaml"] = toYaml
+	funcs["fromYaml"] = fromYaml
+	funcs["required"] = required
+	funcs["include"] = func(name string, data interface{}) (string, error) {
+		buf := bytes.NewBufferString("")
+		if err := tmpl.ExecuteTemplate(buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	return funcs
+}
+
+// toYaml encodes the value as YAML, without trailing newline, e.g. to be used with indent or nindent.
+func toYaml(value interface{}) (string, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// fromYaml decodes the YAML document to a map.
+func fromYaml(document string) (map[string]interface{}, error) {
+	value := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(document), &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// required fails the execution of the template with the message if the value is missing or empty.
+func required(message string, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, errors.New(message)
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return nil, errors.New(message)
+	}
+	return value, nil
+}
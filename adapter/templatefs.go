@@ -0,0 +1,208 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gherrors "github.com/pkg/errors"
+)
+
+// TemplatesFromDir returns the file system of the templates in the directory.
+func TemplatesFromDir(dir string) fs.FS {
+	return os.DirFS(dir)
+}
+
+// TemplatesFromBundle returns the file system of the templates in a zip or tar bundle, optionally gzip compressed.
+// The format is determined by the file extension: .zip, .tar, .tar.gz or .tgz. The bundle is read into memory.
+func TemplatesFromBundle(bundlePath string) (fs.FS, error) {
+	data, err := ioutil.ReadFile(bundlePath)
+	if err != nil {
+		return nil, gherrors.Wrapf(err, "unable to read template bundle %s", bundlePath)
+	}
+
+	name := strings.ToLower(bundlePath)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		bundle, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, gherrors.Wrapf(err, "unable to read zip bundle %s", bundlePath)
+		}
+		return bundle, nil
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, gherrors.Wrapf(err, "unable to read gzip bundle %s", bundlePath)
+		}
+		defer reader.Close()
+		return tarTemplates(reader)
+	case strings.HasSuffix(name, ".tar"):
+		return tarTemplates(bytes.NewReader(data))
+	}
+	return nil, fmt.Errorf("unsupported template bundle %s, expected .zip, .tar, .tar.gz or .tgz", bundlePath)
+}
+
+// tarTemplates reads the regular files of the tar archive into an in-memory file system.
+func tarTemplates(reader io.Reader) (fs.FS, error) {
+	templates := memFS{}
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return templates, nil
+		}
+		if err != nil {
+			return nil, gherrors.Wrapf(err, "unable to read tar bundle")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(archive)
+		if err != nil {
+			return nil, gherrors.Wrapf(err, "unable to read %s from tar bundle", header.Name)
+		}
+		templates[path.Clean(strings.TrimPrefix(header.Name, "/"))] = data
+	}
+}
+
+// memFS is a read-only in-memory file system of regular files by their slash-separated paths.
+// Directories are implied by the paths of the files.
+type memFS map[string][]byte
+
+func (m memFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := m[name]; ok {
+		return &memFile{info: memInfo{name: path.Base(name), size: int64(len(data))}, Reader: bytes.NewReader(data)}, nil
+	}
+	entries, err := m.ReadDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memDir{info: memInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+}
+
+// ReadDir returns the entries of the directory sorted by name.
+func (m memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+	children := map[string]memInfo{}
+	for file, data := range m {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		child := strings.TrimPrefix(file, prefix)
+		if i := strings.Index(child, "/"); i >= 0 {
+			children[child[:i]] = memInfo{name: child[:i], dir: true}
+			continue
+		}
+		children[child] = memInfo{name: child, size: int64(len(data))}
+	}
+	if len(children) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, child := range children {
+		entries = append(entries, child)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// memInfo describes a file or directory of a memFS, it is both its fs.FileInfo and its fs.DirEntry.
+type memInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memInfo) Name() string       { return i.name }
+func (i memInfo) Size() int64        { return i.size }
+func (i memInfo) ModTime() time.Time { return time.Time{} }
+func (i memInfo) IsDir() bool        { return i.dir }
+func (i memInfo) Sys() interface{}   { return nil }
+func (i memInfo) Type() fs.FileMode  { return i.Mode().Type() }
+
+func (i memInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+func (i memInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+// memFile is an open file of a memFS.
+type memFile struct {
+	*bytes.Reader
+	info memInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+// memDir is an open directory of a memFS.
+type memDir struct {
+	info    memInfo
+	entries []fs.DirEntry
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir returns the next n entries of the directory, or all remaining entries if n is not positive.
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if n <= 0 || n > len(d.entries) {
+		if n > 0 && len(d.entries) == 0 {
+			return nil, io.EOF
+		}
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// templateSource returns the file system and the name of the template in it. Without Templates file system,
+// the template path is a path in the OS file system, and the file system is the directory of the template.
+func (h *BaseHandler) templateSource(templatePath string) (fs.FS, string) {
+	if h.Templates != nil {
+		return h.Templates, path.Clean(strings.TrimPrefix(filepath.ToSlash(templatePath), "/"))
+	}
+	return os.DirFS(filepath.Dir(templatePath)), filepath.Base(templatePath)
+}
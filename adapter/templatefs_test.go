@@ -0,0 +1,161 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+var bundleFiles = []struct {
+	name    string
+	content string
+}{
+	{name: "mesh/configmap.yaml", content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .name }}
+  labels:
+{{ include "_labels.tpl" . | indent 4 }}
+data:
+  mesh: istio
+`},
+	{name: "mesh/_labels.tpl", content: "app: {{ .name }}"},
+}
+
+func writeTarBundle(t *testing.T, w io.Writer) {
+	archive := tar.NewWriter(w)
+	if err := archive.WriteHeader(&tar.Header{Name: "mesh/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range bundleFiles {
+		if err := archive.WriteHeader(&tar.Header{Name: file.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(file.content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZipBundle(t *testing.T, w io.Writer) {
+	archive := zip.NewWriter(w)
+	if _, err := archive.Create("mesh/"); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range bundleFiles {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeBundle writes the bundle files to a bundle with the given name in a temporary directory, and returns its path.
+func writeBundle(t *testing.T, name string) string {
+	bundlePath := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	switch filepath.Ext(name) {
+	case ".zip":
+		writeZipBundle(t, f)
+	case ".tgz":
+		compressed := gzip.NewWriter(f)
+		writeTarBundle(t, compressed)
+		if err := compressed.Close(); err != nil {
+			t.Fatal(err)
+		}
+	default:
+		writeTarBundle(t, f)
+	}
+	return bundlePath
+}
+
+func TestTemplatesFromBundle(t *testing.T) {
+	for _, name := range []string{"templates.tar", "templates.tgz", "templates.zip"} {
+		t.Run(name, func(t *testing.T) {
+			templates, err := TemplatesFromBundle(writeBundle(t, name))
+			if err != nil {
+				t.Fatalf("TemplatesFromBundle() error = %v", err)
+			}
+			if err := fstest.TestFS(templates, "mesh/configmap.yaml", "mesh/_labels.tpl"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// testConfig is a config.Handler without configuration.
+type testConfig struct{}
+
+func (testConfig) SetKey(string, string)          {}
+func (testConfig) GetKey(string) string           { return "" }
+func (testConfig) Server(interface{}) error       { return nil }
+func (testConfig) MeshSpec(interface{}) error     { return nil }
+func (testConfig) MeshInstance(interface{}) error { return nil }
+func (testConfig) Operations(interface{}) error   { return nil }
+
+func TestApplyKubernetesTemplateFromBundle(t *testing.T) {
+	templates, err := TemplatesFromBundle(writeBundle(t, "templates.tgz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	instance := fakeInstance(&metav1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+	})
+	instance.DynamicKubeClient = dynamicClient
+	h := &BaseHandler{Config: testConfig{}, Templates: templates}
+	h.instances.add(instance, h.setDefaultInstance)
+
+	request := OperationRequest{OperationName: "install", OperationID: "op1", Namespace: "default"}
+	if err := h.ApplyKubernetesTemplate(request, Operation{}, map[string]interface{}{"name": "mesh-config"}, "mesh/configmap.yaml"); err != nil {
+		t.Fatalf("ApplyKubernetesTemplate() error = %v", err)
+	}
+
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	configMap, err := dynamicClient.Resource(configMaps).Namespace("default").Get(context.Background(), "mesh-config", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the config map to be created, got %v", err)
+	}
+	if app := configMap.GetLabels()["app"]; app != "mesh-config" {
+		t.Errorf("expected label app=mesh-config from the partial, got %q", app)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Michael Gfeller
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	gherrors "github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/kube-openapi/pkg/util/proto/validation"
+	"k8s.io/kubectl/pkg/util/openapi"
+)
+
+// SchemaError is a violation of the OpenAPI schema by a document of a manifest.
+type SchemaError struct {
+	// Document is the index of the document in the manifest, starting at 0.
+	Document int    `json:"document"`
+	Kind     string `json:"kind,omitempty"`
+	Name     string `json:"name,omitempty"`
+	// Path is the path of the invalid field, e.g. "Deployment.spec.replicas".
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e SchemaError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("document %d (%s %s): %s: %s", e.Document, e.Kind, e.Name, e.Path, e.Message)
+	}
+	return fmt.Sprintf("document %d (%s %s): %s", e.Document, e.Kind, e.Name, e.Message)
+}
+
+// ValidationError is returned if documents of a manifest do not conform to the OpenAPI schemas of their kinds.
+type ValidationError struct {
+	Errors []SchemaError `json:"errors,omitempty"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("invalid manifest: %s", strings.Join(messages, "; "))
+}
+
+// schemaCache holds OpenAPI schemas once they are loaded. It is safe for concurrent use.
+type schemaCache struct {
+	mu        sync.Mutex
+	resources openapi.Resources
+}
+
+// get returns the cached schemas, loading them if they have not been loaded yet.
+func (c *schemaCache) get(load func() (openapi.Resources, error)) (openapi.Resources, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resources == nil {
+		resources, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.resources = resources
+	}
+	return c.resources, nil
+}
+
+// openAPISchemas returns the OpenAPI schemas used for validation, from the local bundle at OpenAPISchemaPath if set,
+// otherwise from the discovery endpoint of the instance.
+func (h *BaseHandler) openAPISchemas(instance *Instance) (openapi.Resources, error) {
+	if h.OpenAPISchemaPath != "" {
+		return h.schemas.get(func() (openapi.Resources, error) {
+			data, err := ioutil.ReadFile(h.OpenAPISchemaPath)
+			if err != nil {
+				return nil, gherrors.Wrapf(err, "unable to read openapi schema %s", h.OpenAPISchemaPath)
+			}
+			info, err := compiler.ReadInfoFromBytes(h.OpenAPISchemaPath, data)
+			if err != nil {
+				return nil, gherrors.Wrapf(err, "unable to parse openapi schema %s", h.OpenAPISchemaPath)
+			}
+			document, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+			if err != nil {
+				return nil, gherrors.Wrapf(err, "unable to parse openapi schema %s", h.OpenAPISchemaPath)
+			}
+			return openapi.NewOpenAPIData(document)
+		})
+	}
+	return instance.schemas.get(func() (openapi.Resources, error) {
+		document, err := instance.discoveryClient.OpenAPISchema()
+		if err != nil {
+			return nil, gherrors.Wrapf(err, "unable to get openapi schema")
+		}
+		return openapi.NewOpenAPIData(document)
+	})
+}
+
+// validateManifest validates every document of the manifest against the OpenAPI schema of its kind.
+// Documents of kinds without schema, e.g. custom resources of CRDs created by the same manifest, are not validated.
+func (h *BaseHandler) validateManifest(yamlFileContents string, opts applyOptions) error {
+	resources, err := h.openAPISchemas(opts.instance)
+	if err != nil {
+		return err
+	}
+	yamls, err := h.splitYAML(yamlFileContents)
+	if err != nil {
+		return err
+	}
+
+	schemaErrors := []SchemaError{}
+	for index, yml := range yamls {
+		if strings.TrimSpace(yml) == "" {
+			continue
+		}
+		schemaErrors = append(schemaErrors, validateDocument(resources, index, []byte(yml))...)
+	}
+	if len(schemaErrors) == 0 {
+		return nil
+	}
+
+	validationErr := &ValidationError{Errors: schemaErrors}
+	logrus.Error(validationErr)
+	if h.Channel != nil {
+		h.StreamErr(&Event{
+			Operationid: opts.operationID,
+			Summary:     "Manifest validation failed",
+			Details:     validationErr.Error(),
+		}, ErrValidateManifest(validationErr))
+	}
+	return validationErr
+}
+
+// validateDocument validates the objects of a document, expanding lists.
+func validateDocument(resources openapi.Resources, index int, document []byte) []SchemaError {
+	data, err := yaml.ToJSON(document)
+	if err != nil {
+		return []SchemaError{{Document: index, Message: err.Error()}}
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return []SchemaError{{Document: index, Message: err.Error()}}
+	}
+	if object == nil {
+		return nil
+	}
+
+	objects := []map[string]interface{}{object}
+	if items, ok := object["items"].([]interface{}); ok && strings.HasSuffix(fmt.Sprint(object["kind"]), "List") {
+		objects = objects[:0]
+		for _, item := range items {
+			if o, ok := item.(map[string]interface{}); ok {
+				objects = append(objects, o)
+			}
+		}
+	}
+
+	schemaErrors := []SchemaError{}
+	for _, o := range objects {
+		kind, _ := o["kind"].(string)
+		apiVersion, _ := o["apiVersion"].(string)
+		name := ""
+		if metadata, ok := o["metadata"].(map[string]interface{}); ok {
+			name, _ = metadata["name"].(string)
+		}
+		if kind == "" || apiVersion == "" {
+			schemaErrors = append(schemaErrors, SchemaError{Document: index, Kind: kind, Name: name, Message: "apiVersion and kind must be set"})
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			schemaErrors = append(schemaErrors, SchemaError{Document: index, Kind: kind, Name: name, Message: err.Error()})
+			continue
+		}
+		resource := resources.LookupResource(gv.WithKind(kind))
+		if resource == nil {
+			continue
+		}
+		for _, err := range validation.ValidateModel(o, resource, kind) {
+			schemaError := SchemaError{Document: index, Kind: kind, Name: name, Message: err.Error()}
+			if validationErr, ok := err.(validation.ValidationError); ok {
+				schemaError.Path = validationErr.Path
+				schemaError.Message = validationErr.Err.Error()
+			}
+			schemaErrors = append(schemaErrors, schemaError)
+		}
+	}
+	return schemaErrors
+}
@@ -43,6 +43,14 @@ type Service struct {
 	TraceURL  string    `json:"traceurl"`
 	Handler   adapter.Handler
 	Channel   chan interface{}
+	// Jobs runs operations asynchronously if set, ApplyOperation then returns as soon as the operation is accepted,
+	// and its state is available with OperationStatus.
+	Jobs *adapter.JobRunner
+	// Operations makes ApplyOperation idempotent if set, a request with the operation ID of a recent operation
+	// returns the outcome of that operation instead of applying it again.
+	Operations *adapter.OperationCache
+
+	cancels adapter.CancelRegistry
 }
 
 // panicHandler is the handler function to handle panic errors
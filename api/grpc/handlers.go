@@ -15,6 +15,7 @@
 package grpc
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/mgfeller/common-adapter-library/adapter"
@@ -32,6 +33,26 @@ func (s *Service) CreateMeshInstance(ctx context.Context, req *meshes.CreateMesh
 	return &meshes.CreateMeshInstanceResponse{}, nil
 }
 
+// ListMeshInstances is the handler function for the method ListMeshInstances.
+func (s *Service) ListMeshInstances(ctx context.Context, req *meshes.ListMeshInstancesRequest) (*meshes.ListMeshInstancesResponse, error) {
+	instances, err := s.Handler.ListInstances()
+	if err != nil {
+		return nil, err
+	}
+	return &meshes.ListMeshInstancesResponse{
+		Instances: instances,
+	}, nil
+}
+
+// RemoveMeshInstance is the handler function for the method RemoveMeshInstance.
+func (s *Service) RemoveMeshInstance(ctx context.Context, req *meshes.RemoveMeshInstanceRequest) (*meshes.RemoveMeshInstanceResponse, error) {
+	err := s.Handler.RemoveInstance(req.Instance)
+	if err != nil {
+		return nil, err
+	}
+	return &meshes.RemoveMeshInstanceResponse{}, nil
+}
+
 // MeshName is the handler function for the method MeshName.
 func (s *Service) MeshName(ctx context.Context, req *meshes.MeshNameRequest) (*meshes.MeshNameResponse, error) {
 	return &meshes.MeshNameResponse{
@@ -54,24 +75,161 @@ func (s *Service) ApplyOperation(ctx context.Context, req *meshes.ApplyRuleReque
 		OperationName:     req.OpName,
 		Namespace:         req.Namespace,
 		Username:          req.Username,
+		UserGroups:        req.UserGroups,
 		CustomBody:        req.CustomBody,
 		IsDeleteOperation: req.DeleteOp,
 		OperationID:       req.OperationId,
+		Instance:          req.Instance,
+		DryRun:            req.DryRun,
+		Parameters:        req.Params,
+		Result:            &adapter.OperationResult{},
+	}
+	if s.Jobs != nil {
+		operationID, err := s.Jobs.Submit(operation, s.Handler.ApplyOperation)
+		if err != nil {
+			return &meshes.ApplyRuleResponse{
+				Error:       err.Error(),
+				OperationId: req.OperationId,
+			}, err
+		}
+		return &meshes.ApplyRuleResponse{
+			Error:       "",
+			OperationId: operationID,
+		}, nil
+	}
+
+	result := operation.Result
+	var err error
+	if s.Operations != nil {
+		// the cache applies the operation detached from the request, and cancels it on CancelOperation
+		result, err = s.Operations.Apply(ctx, operation, s.Handler.ApplyOperation)
+	} else {
+		ctx, done := s.cancels.WithCancel(ctx, operation.OperationID)
+		defer done()
+		err = s.Handler.ApplyOperation(ctx, operation.WithContext(ctx))
 	}
-	err := s.Handler.ApplyOperation(ctx, operation)
 	if err != nil {
 		return &meshes.ApplyRuleResponse{
-			Error:       err.Error(),
-			OperationId: req.OperationId,
+			Error:              err.Error(),
+			OperationId:        req.OperationId,
+			Diffs:              resourceDiffs(result),
+			MissingPermissions: missingPermissions(result),
+			Objects:            objectResults(result),
 		}, err
 	}
 
 	return &meshes.ApplyRuleResponse{
 		Error:       "",
 		OperationId: req.OperationId,
+		Diffs:       resourceDiffs(result),
+		Objects:     objectResults(result),
+	}, nil
+}
+
+// OperationStatus is the handler function for the method OperationStatus.
+func (s *Service) OperationStatus(ctx context.Context, req *meshes.OperationStatusRequest) (*meshes.OperationStatusResponse, error) {
+	if s.Jobs == nil {
+		return nil, adapter.ErrJobNotFound(req.OperationId)
+	}
+	job, err := s.Jobs.Job(req.OperationId)
+	if err != nil {
+		return nil, err
+	}
+	return &meshes.OperationStatusResponse{
+		OperationId:        job.OperationID,
+		OpName:             job.OperationName,
+		State:              string(job.State),
+		Error:              job.Error,
+		Diffs:              resourceDiffs(job.Result),
+		MissingPermissions: missingPermissions(job.Result),
+		Objects:            objectResults(job.Result),
 	}, nil
 }
 
+// CancelOperation is the handler function for the method CancelOperation.
+func (s *Service) CancelOperation(ctx context.Context, req *meshes.CancelOperationRequest) (*meshes.CancelOperationResponse, error) {
+	canceled := s.cancels.Cancel(req.OperationId)
+	if s.Operations != nil && s.Operations.Cancel(req.OperationId) {
+		canceled = true
+	}
+	if s.Jobs != nil && s.Jobs.Cancel(req.OperationId) {
+		canceled = true
+	}
+	if !canceled {
+		return nil, adapter.ErrJobNotFound(req.OperationId)
+	}
+
+	event := &adapter.Event{
+		Operationid: req.OperationId,
+		EType:       int32(meshes.EventType_WARN),
+		Summary:     "Operation canceled",
+		Details:     fmt.Sprintf("Operation %s has been canceled on request", req.OperationId),
+	}
+	go func() {
+		s.Channel <- event
+	}()
+	return &meshes.CancelOperationResponse{}, nil
+}
+
+// missingPermissions converts the permissions found missing by the pre-flight check to their textual representation.
+func missingPermissions(result *adapter.OperationResult) []string {
+	permissions := make([]string, 0)
+	for _, permission := range result.MissingPermissions() {
+		permissions = append(permissions, permission.String())
+	}
+	return permissions
+}
+
+// resourceDiffs converts the changes previewed by a dry run to their protobuf representation.
+func resourceDiffs(result *adapter.OperationResult) []*meshes.ResourceDiff {
+	diffs := make([]*meshes.ResourceDiff, 0)
+	for _, diff := range result.Diffs() {
+		diffs = append(diffs, &meshes.ResourceDiff{
+			Action:    string(diff.Action),
+			Kind:      diff.Kind,
+			Namespace: diff.Namespace,
+			Name:      diff.Name,
+			Diff:      diff.Diff,
+		})
+	}
+	return diffs
+}
+
+// objectResults converts the outcome of the applied objects to their protobuf representation.
+func objectResults(result *adapter.OperationResult) []*meshes.ObjectResult {
+	objects := make([]*meshes.ObjectResult, 0)
+	for _, object := range result.Objects() {
+		objects = append(objects, &meshes.ObjectResult{
+			Kind:      object.Kind,
+			Namespace: object.Namespace,
+			Name:      object.Name,
+			Outcome:   string(object.Outcome),
+			Error:     object.Error,
+		})
+	}
+	return objects
+}
+
+// operationParameters converts the parameters declared by an operation to their protobuf representation.
+func operationParameters(parameters []adapter.Parameter) []*meshes.OperationParameter {
+	result := make([]*meshes.OperationParameter, 0, len(parameters))
+	for _, parameter := range parameters {
+		parameterType := parameter.Type
+		if parameterType == "" {
+			parameterType = adapter.ParameterTypeString
+		}
+		result = append(result, &meshes.OperationParameter{
+			Name:         parameter.Name,
+			Type:         string(parameterType),
+			DefaultValue: parameter.Default,
+			Required:     parameter.Required,
+			Enum:         parameter.Enum,
+			Description:  parameter.Description,
+		})
+	}
+	return result
+}
+
 // SupportedOperations is the handler function for the method SupportedOperations.
 func (s *Service) SupportedOperations(ctx context.Context, req *meshes.SupportedOperationsRequest) (*meshes.SupportedOperationsResponse, error) {
 	result, err := s.Handler.ListOperations()
@@ -82,9 +240,10 @@ func (s *Service) SupportedOperations(ctx context.Context, req *meshes.Supported
 	operations := make([]*meshes.SupportedOperation, 0)
 	for key, val := range result {
 		operations = append(operations, &meshes.SupportedOperation{
-			Key:      key,
-			Value:    val.Properties["description"],
-			Category: meshes.OpCategory(val.Type),
+			Key:        key,
+			Value:      val.Properties[adapter.DescriptionKey],
+			Category:   meshes.OpCategory(val.Type),
+			Parameters: operationParameters(val.Parameters),
 		})
 	}
 
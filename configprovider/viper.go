@@ -88,5 +88,9 @@ func (v *Viper) MeshInstance(result interface{}) error {
 }
 
 func (v *Viper) Operations(result interface{}) error {
-	return v.instance.Sub(OperationsKey).Unmarshal(result)
+	operations := v.instance.Sub(OperationsKey)
+	if operations == nil { // no operations are configured
+		return nil
+	}
+	return operations.Unmarshal(result)
 }
@@ -1,16 +1,19 @@
 module github.com/mgfeller/common-adapter-library
 
-go 1.13
+go 1.16
 
 replace github.com/kudobuilder/kuttl => github.com/layer5io/kuttl v0.4.1-0.20200806180306-b7e46afd657f
 
 require (
+	github.com/Masterminds/sprig/v3 v3.1.0
 	github.com/ghodss/yaml v1.0.0
 	github.com/golang/protobuf v1.4.2
+	github.com/google/go-cmp v0.5.1
+	github.com/google/uuid v1.1.1
+	github.com/googleapis/gnostic v0.1.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.2.0
-	github.com/imdario/mergo v0.3.8 // indirect
 	github.com/layer5io/gokit v0.1.12
-	github.com/pkg/errors v0.8.1
+	github.com/pkg/errors v0.9.1
 	github.com/sirupsen/logrus v1.6.0
 	github.com/spf13/viper v1.7.1
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc v0.11.0
@@ -19,8 +22,11 @@ require (
 	go.opentelemetry.io/otel/sdk v0.11.0
 	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 	google.golang.org/grpc v1.31.0
-	gopkg.in/yaml.v2 v2.3.0 // indirect
+	helm.sh/helm/v3 v3.3.4
 	k8s.io/api v0.18.8
 	k8s.io/apimachinery v0.18.8
 	k8s.io/client-go v0.18.8
+	k8s.io/kube-openapi v0.0.0-20200410145947-61e04a5be9a6
+	k8s.io/kubectl v0.18.8
+	sigs.k8s.io/kustomize/api v0.8.8
 )
@@ -52,7 +52,7 @@ func (x OpCategory) String() string {
 	return proto.EnumName(OpCategory_name, int32(x))
 }
 func (OpCategory) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{0}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{0}
 }
 
 type EventType int32
@@ -78,7 +78,7 @@ func (x EventType) String() string {
 	return proto.EnumName(EventType_name, int32(x))
 }
 func (EventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{1}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{1}
 }
 
 type CreateMeshInstanceRequest struct {
@@ -93,7 +93,7 @@ func (m *CreateMeshInstanceRequest) Reset()         { *m = CreateMeshInstanceReq
 func (m *CreateMeshInstanceRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateMeshInstanceRequest) ProtoMessage()    {}
 func (*CreateMeshInstanceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{0}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{0}
 }
 func (m *CreateMeshInstanceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateMeshInstanceRequest.Unmarshal(m, b)
@@ -137,7 +137,7 @@ func (m *CreateMeshInstanceResponse) Reset()         { *m = CreateMeshInstanceRe
 func (m *CreateMeshInstanceResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateMeshInstanceResponse) ProtoMessage()    {}
 func (*CreateMeshInstanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{1}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{1}
 }
 func (m *CreateMeshInstanceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateMeshInstanceResponse.Unmarshal(m, b)
@@ -157,6 +157,142 @@ func (m *CreateMeshInstanceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateMeshInstanceResponse proto.InternalMessageInfo
 
+type ListMeshInstancesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMeshInstancesRequest) Reset()         { *m = ListMeshInstancesRequest{} }
+func (m *ListMeshInstancesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMeshInstancesRequest) ProtoMessage()    {}
+func (*ListMeshInstancesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{2}
+}
+func (m *ListMeshInstancesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMeshInstancesRequest.Unmarshal(m, b)
+}
+func (m *ListMeshInstancesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMeshInstancesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListMeshInstancesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMeshInstancesRequest.Merge(dst, src)
+}
+func (m *ListMeshInstancesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMeshInstancesRequest.Size(m)
+}
+func (m *ListMeshInstancesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMeshInstancesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMeshInstancesRequest proto.InternalMessageInfo
+
+type ListMeshInstancesResponse struct {
+	Instances            []string `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMeshInstancesResponse) Reset()         { *m = ListMeshInstancesResponse{} }
+func (m *ListMeshInstancesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMeshInstancesResponse) ProtoMessage()    {}
+func (*ListMeshInstancesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{3}
+}
+func (m *ListMeshInstancesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMeshInstancesResponse.Unmarshal(m, b)
+}
+func (m *ListMeshInstancesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMeshInstancesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListMeshInstancesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMeshInstancesResponse.Merge(dst, src)
+}
+func (m *ListMeshInstancesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMeshInstancesResponse.Size(m)
+}
+func (m *ListMeshInstancesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMeshInstancesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMeshInstancesResponse proto.InternalMessageInfo
+
+func (m *ListMeshInstancesResponse) GetInstances() []string {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+type RemoveMeshInstanceRequest struct {
+	Instance             string   `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveMeshInstanceRequest) Reset()         { *m = RemoveMeshInstanceRequest{} }
+func (m *RemoveMeshInstanceRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveMeshInstanceRequest) ProtoMessage()    {}
+func (*RemoveMeshInstanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{4}
+}
+func (m *RemoveMeshInstanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveMeshInstanceRequest.Unmarshal(m, b)
+}
+func (m *RemoveMeshInstanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveMeshInstanceRequest.Marshal(b, m, deterministic)
+}
+func (dst *RemoveMeshInstanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveMeshInstanceRequest.Merge(dst, src)
+}
+func (m *RemoveMeshInstanceRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveMeshInstanceRequest.Size(m)
+}
+func (m *RemoveMeshInstanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveMeshInstanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveMeshInstanceRequest proto.InternalMessageInfo
+
+func (m *RemoveMeshInstanceRequest) GetInstance() string {
+	if m != nil {
+		return m.Instance
+	}
+	return ""
+}
+
+type RemoveMeshInstanceResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveMeshInstanceResponse) Reset()         { *m = RemoveMeshInstanceResponse{} }
+func (m *RemoveMeshInstanceResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveMeshInstanceResponse) ProtoMessage()    {}
+func (*RemoveMeshInstanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{5}
+}
+func (m *RemoveMeshInstanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveMeshInstanceResponse.Unmarshal(m, b)
+}
+func (m *RemoveMeshInstanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveMeshInstanceResponse.Marshal(b, m, deterministic)
+}
+func (dst *RemoveMeshInstanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveMeshInstanceResponse.Merge(dst, src)
+}
+func (m *RemoveMeshInstanceResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveMeshInstanceResponse.Size(m)
+}
+func (m *RemoveMeshInstanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveMeshInstanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveMeshInstanceResponse proto.InternalMessageInfo
+
 type MeshNameRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -167,7 +303,7 @@ func (m *MeshNameRequest) Reset()         { *m = MeshNameRequest{} }
 func (m *MeshNameRequest) String() string { return proto.CompactTextString(m) }
 func (*MeshNameRequest) ProtoMessage()    {}
 func (*MeshNameRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{2}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{6}
 }
 func (m *MeshNameRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MeshNameRequest.Unmarshal(m, b)
@@ -198,7 +334,7 @@ func (m *MeshNameResponse) Reset()         { *m = MeshNameResponse{} }
 func (m *MeshNameResponse) String() string { return proto.CompactTextString(m) }
 func (*MeshNameResponse) ProtoMessage()    {}
 func (*MeshNameResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{3}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{7}
 }
 func (m *MeshNameResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MeshNameResponse.Unmarshal(m, b)
@@ -226,22 +362,26 @@ func (m *MeshNameResponse) GetName() string {
 }
 
 type ApplyRuleRequest struct {
-	OpName               string   `protobuf:"bytes,1,opt,name=opName,proto3" json:"opName,omitempty"`
-	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Username             string   `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	CustomBody           string   `protobuf:"bytes,4,opt,name=custom_body,json=customBody,proto3" json:"custom_body,omitempty"`
-	DeleteOp             bool     `protobuf:"varint,5,opt,name=delete_op,json=deleteOp,proto3" json:"delete_op,omitempty"`
-	OperationId          string   `protobuf:"bytes,6,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	OpName               string            `protobuf:"bytes,1,opt,name=opName,proto3" json:"opName,omitempty"`
+	Namespace            string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Username             string            `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	CustomBody           string            `protobuf:"bytes,4,opt,name=custom_body,json=customBody,proto3" json:"custom_body,omitempty"`
+	DeleteOp             bool              `protobuf:"varint,5,opt,name=delete_op,json=deleteOp,proto3" json:"delete_op,omitempty"`
+	OperationId          string            `protobuf:"bytes,6,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	DryRun               bool              `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Instance             string            `protobuf:"bytes,8,opt,name=instance,proto3" json:"instance,omitempty"`
+	UserGroups           []string          `protobuf:"bytes,9,rep,name=user_groups,json=userGroups,proto3" json:"user_groups,omitempty"`
+	Params               map[string]string `protobuf:"bytes,10,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ApplyRuleRequest) Reset()         { *m = ApplyRuleRequest{} }
 func (m *ApplyRuleRequest) String() string { return proto.CompactTextString(m) }
 func (*ApplyRuleRequest) ProtoMessage()    {}
 func (*ApplyRuleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{4}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{8}
 }
 func (m *ApplyRuleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApplyRuleRequest.Unmarshal(m, b)
@@ -303,19 +443,50 @@ func (m *ApplyRuleRequest) GetOperationId() string {
 	return ""
 }
 
+func (m *ApplyRuleRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ApplyRuleRequest) GetInstance() string {
+	if m != nil {
+		return m.Instance
+	}
+	return ""
+}
+
+func (m *ApplyRuleRequest) GetUserGroups() []string {
+	if m != nil {
+		return m.UserGroups
+	}
+	return nil
+}
+
+func (m *ApplyRuleRequest) GetParams() map[string]string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
 type ApplyRuleResponse struct {
-	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	OperationId          string   `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Error                string          `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	OperationId          string          `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Diffs                []*ResourceDiff `protobuf:"bytes,3,rep,name=diffs,proto3" json:"diffs,omitempty"`
+	MissingPermissions   []string        `protobuf:"bytes,4,rep,name=missing_permissions,json=missingPermissions,proto3" json:"missing_permissions,omitempty"`
+	Objects              []*ObjectResult `protobuf:"bytes,5,rep,name=objects,proto3" json:"objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *ApplyRuleResponse) Reset()         { *m = ApplyRuleResponse{} }
 func (m *ApplyRuleResponse) String() string { return proto.CompactTextString(m) }
 func (*ApplyRuleResponse) ProtoMessage()    {}
 func (*ApplyRuleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{5}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{9}
 }
 func (m *ApplyRuleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApplyRuleResponse.Unmarshal(m, b)
@@ -349,6 +520,359 @@ func (m *ApplyRuleResponse) GetOperationId() string {
 	return ""
 }
 
+func (m *ApplyRuleResponse) GetDiffs() []*ResourceDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
+func (m *ApplyRuleResponse) GetMissingPermissions() []string {
+	if m != nil {
+		return m.MissingPermissions
+	}
+	return nil
+}
+
+func (m *ApplyRuleResponse) GetObjects() []*ObjectResult {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
+type ResourceDiff struct {
+	Action               string   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Kind                 string   `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Diff                 string   `protobuf:"bytes,5,opt,name=diff,proto3" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceDiff) Reset()         { *m = ResourceDiff{} }
+func (m *ResourceDiff) String() string { return proto.CompactTextString(m) }
+func (*ResourceDiff) ProtoMessage()    {}
+func (*ResourceDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{10}
+}
+func (m *ResourceDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceDiff.Unmarshal(m, b)
+}
+func (m *ResourceDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceDiff.Marshal(b, m, deterministic)
+}
+func (dst *ResourceDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceDiff.Merge(dst, src)
+}
+func (m *ResourceDiff) XXX_Size() int {
+	return xxx_messageInfo_ResourceDiff.Size(m)
+}
+func (m *ResourceDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceDiff proto.InternalMessageInfo
+
+func (m *ResourceDiff) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetDiff() string {
+	if m != nil {
+		return m.Diff
+	}
+	return ""
+}
+
+type ObjectResult struct {
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Outcome              string   `protobuf:"bytes,4,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectResult) Reset()         { *m = ObjectResult{} }
+func (m *ObjectResult) String() string { return proto.CompactTextString(m) }
+func (*ObjectResult) ProtoMessage()    {}
+func (*ObjectResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{11}
+}
+func (m *ObjectResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ObjectResult.Unmarshal(m, b)
+}
+func (m *ObjectResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ObjectResult.Marshal(b, m, deterministic)
+}
+func (dst *ObjectResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectResult.Merge(dst, src)
+}
+func (m *ObjectResult) XXX_Size() int {
+	return xxx_messageInfo_ObjectResult.Size(m)
+}
+func (m *ObjectResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectResult proto.InternalMessageInfo
+
+func (m *ObjectResult) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ObjectResult) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ObjectResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ObjectResult) GetOutcome() string {
+	if m != nil {
+		return m.Outcome
+	}
+	return ""
+}
+
+func (m *ObjectResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type OperationStatusRequest struct {
+	OperationId          string   `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OperationStatusRequest) Reset()         { *m = OperationStatusRequest{} }
+func (m *OperationStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*OperationStatusRequest) ProtoMessage()    {}
+func (*OperationStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{12}
+}
+func (m *OperationStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OperationStatusRequest.Unmarshal(m, b)
+}
+func (m *OperationStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OperationStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *OperationStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationStatusRequest.Merge(dst, src)
+}
+func (m *OperationStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_OperationStatusRequest.Size(m)
+}
+func (m *OperationStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OperationStatusRequest proto.InternalMessageInfo
+
+func (m *OperationStatusRequest) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+type OperationStatusResponse struct {
+	OperationId          string          `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	OpName               string          `protobuf:"bytes,2,opt,name=op_name,json=opName,proto3" json:"op_name,omitempty"`
+	State                string          `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Error                string          `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Diffs                []*ResourceDiff `protobuf:"bytes,5,rep,name=diffs,proto3" json:"diffs,omitempty"`
+	MissingPermissions   []string        `protobuf:"bytes,6,rep,name=missing_permissions,json=missingPermissions,proto3" json:"missing_permissions,omitempty"`
+	Objects              []*ObjectResult `protobuf:"bytes,7,rep,name=objects,proto3" json:"objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *OperationStatusResponse) Reset()         { *m = OperationStatusResponse{} }
+func (m *OperationStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*OperationStatusResponse) ProtoMessage()    {}
+func (*OperationStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{13}
+}
+func (m *OperationStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OperationStatusResponse.Unmarshal(m, b)
+}
+func (m *OperationStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OperationStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *OperationStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationStatusResponse.Merge(dst, src)
+}
+func (m *OperationStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_OperationStatusResponse.Size(m)
+}
+func (m *OperationStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OperationStatusResponse proto.InternalMessageInfo
+
+func (m *OperationStatusResponse) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func (m *OperationStatusResponse) GetOpName() string {
+	if m != nil {
+		return m.OpName
+	}
+	return ""
+}
+
+func (m *OperationStatusResponse) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *OperationStatusResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *OperationStatusResponse) GetDiffs() []*ResourceDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
+func (m *OperationStatusResponse) GetMissingPermissions() []string {
+	if m != nil {
+		return m.MissingPermissions
+	}
+	return nil
+}
+
+func (m *OperationStatusResponse) GetObjects() []*ObjectResult {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
+type CancelOperationRequest struct {
+	OperationId          string   `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOperationRequest) Reset()         { *m = CancelOperationRequest{} }
+func (m *CancelOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationRequest) ProtoMessage()    {}
+func (*CancelOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{14}
+}
+func (m *CancelOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationRequest.Unmarshal(m, b)
+}
+func (m *CancelOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationRequest.Marshal(b, m, deterministic)
+}
+func (dst *CancelOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationRequest.Merge(dst, src)
+}
+func (m *CancelOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationRequest.Size(m)
+}
+func (m *CancelOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationRequest proto.InternalMessageInfo
+
+func (m *CancelOperationRequest) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+type CancelOperationResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOperationResponse) Reset()         { *m = CancelOperationResponse{} }
+func (m *CancelOperationResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationResponse) ProtoMessage()    {}
+func (*CancelOperationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{15}
+}
+func (m *CancelOperationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationResponse.Unmarshal(m, b)
+}
+func (m *CancelOperationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationResponse.Marshal(b, m, deterministic)
+}
+func (dst *CancelOperationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationResponse.Merge(dst, src)
+}
+func (m *CancelOperationResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationResponse.Size(m)
+}
+func (m *CancelOperationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationResponse proto.InternalMessageInfo
+
 type SupportedOperationsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -359,7 +883,7 @@ func (m *SupportedOperationsRequest) Reset()         { *m = SupportedOperationsR
 func (m *SupportedOperationsRequest) String() string { return proto.CompactTextString(m) }
 func (*SupportedOperationsRequest) ProtoMessage()    {}
 func (*SupportedOperationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{6}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{16}
 }
 func (m *SupportedOperationsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportedOperationsRequest.Unmarshal(m, b)
@@ -391,7 +915,7 @@ func (m *SupportedOperationsResponse) Reset()         { *m = SupportedOperations
 func (m *SupportedOperationsResponse) String() string { return proto.CompactTextString(m) }
 func (*SupportedOperationsResponse) ProtoMessage()    {}
 func (*SupportedOperationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{7}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{17}
 }
 func (m *SupportedOperationsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportedOperationsResponse.Unmarshal(m, b)
@@ -426,19 +950,20 @@ func (m *SupportedOperationsResponse) GetError() string {
 }
 
 type SupportedOperation struct {
-	Key                  string     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                string     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Category             OpCategory `protobuf:"varint,3,opt,name=category,proto3,enum=meshes.OpCategory" json:"category,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Key                  string                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string                `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Category             OpCategory            `protobuf:"varint,3,opt,name=category,proto3,enum=meshes.OpCategory" json:"category,omitempty"`
+	Parameters           []*OperationParameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *SupportedOperation) Reset()         { *m = SupportedOperation{} }
 func (m *SupportedOperation) String() string { return proto.CompactTextString(m) }
 func (*SupportedOperation) ProtoMessage()    {}
 func (*SupportedOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{8}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{18}
 }
 func (m *SupportedOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SupportedOperation.Unmarshal(m, b)
@@ -479,6 +1004,91 @@ func (m *SupportedOperation) GetCategory() OpCategory {
 	return OpCategory_INSTALL
 }
 
+func (m *SupportedOperation) GetParameters() []*OperationParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+type OperationParameter struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	DefaultValue         string   `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	Required             bool     `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Enum                 []string `protobuf:"bytes,5,rep,name=enum,proto3" json:"enum,omitempty"`
+	Description          string   `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OperationParameter) Reset()         { *m = OperationParameter{} }
+func (m *OperationParameter) String() string { return proto.CompactTextString(m) }
+func (*OperationParameter) ProtoMessage()    {}
+func (*OperationParameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{19}
+}
+func (m *OperationParameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OperationParameter.Unmarshal(m, b)
+}
+func (m *OperationParameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OperationParameter.Marshal(b, m, deterministic)
+}
+func (dst *OperationParameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationParameter.Merge(dst, src)
+}
+func (m *OperationParameter) XXX_Size() int {
+	return xxx_messageInfo_OperationParameter.Size(m)
+}
+func (m *OperationParameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationParameter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OperationParameter proto.InternalMessageInfo
+
+func (m *OperationParameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *OperationParameter) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *OperationParameter) GetDefaultValue() string {
+	if m != nil {
+		return m.DefaultValue
+	}
+	return ""
+}
+
+func (m *OperationParameter) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *OperationParameter) GetEnum() []string {
+	if m != nil {
+		return m.Enum
+	}
+	return nil
+}
+
+func (m *OperationParameter) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
 type EventsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -489,7 +1099,7 @@ func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
 func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
 func (*EventsRequest) ProtoMessage()    {}
 func (*EventsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{9}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{20}
 }
 func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EventsRequest.Unmarshal(m, b)
@@ -523,7 +1133,7 @@ func (m *EventsResponse) Reset()         { *m = EventsResponse{} }
 func (m *EventsResponse) String() string { return proto.CompactTextString(m) }
 func (*EventsResponse) ProtoMessage()    {}
 func (*EventsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meshops_70742207f69c0a34, []int{10}
+	return fileDescriptor_meshops_f7dc18e50c5d38c0, []int{21}
 }
 func (m *EventsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EventsResponse.Unmarshal(m, b)
@@ -574,13 +1184,25 @@ func (m *EventsResponse) GetOperationId() string {
 func init() {
 	proto.RegisterType((*CreateMeshInstanceRequest)(nil), "meshes.CreateMeshInstanceRequest")
 	proto.RegisterType((*CreateMeshInstanceResponse)(nil), "meshes.CreateMeshInstanceResponse")
+	proto.RegisterType((*ListMeshInstancesRequest)(nil), "meshes.ListMeshInstancesRequest")
+	proto.RegisterType((*ListMeshInstancesResponse)(nil), "meshes.ListMeshInstancesResponse")
+	proto.RegisterType((*RemoveMeshInstanceRequest)(nil), "meshes.RemoveMeshInstanceRequest")
+	proto.RegisterType((*RemoveMeshInstanceResponse)(nil), "meshes.RemoveMeshInstanceResponse")
 	proto.RegisterType((*MeshNameRequest)(nil), "meshes.MeshNameRequest")
 	proto.RegisterType((*MeshNameResponse)(nil), "meshes.MeshNameResponse")
 	proto.RegisterType((*ApplyRuleRequest)(nil), "meshes.ApplyRuleRequest")
+	proto.RegisterMapType((map[string]string)(nil), "meshes.ApplyRuleRequest.ParamsEntry")
 	proto.RegisterType((*ApplyRuleResponse)(nil), "meshes.ApplyRuleResponse")
+	proto.RegisterType((*ResourceDiff)(nil), "meshes.ResourceDiff")
+	proto.RegisterType((*ObjectResult)(nil), "meshes.ObjectResult")
+	proto.RegisterType((*OperationStatusRequest)(nil), "meshes.OperationStatusRequest")
+	proto.RegisterType((*OperationStatusResponse)(nil), "meshes.OperationStatusResponse")
+	proto.RegisterType((*CancelOperationRequest)(nil), "meshes.CancelOperationRequest")
+	proto.RegisterType((*CancelOperationResponse)(nil), "meshes.CancelOperationResponse")
 	proto.RegisterType((*SupportedOperationsRequest)(nil), "meshes.SupportedOperationsRequest")
 	proto.RegisterType((*SupportedOperationsResponse)(nil), "meshes.SupportedOperationsResponse")
 	proto.RegisterType((*SupportedOperation)(nil), "meshes.SupportedOperation")
+	proto.RegisterType((*OperationParameter)(nil), "meshes.OperationParameter")
 	proto.RegisterType((*EventsRequest)(nil), "meshes.EventsRequest")
 	proto.RegisterType((*EventsResponse)(nil), "meshes.EventsResponse")
 	proto.RegisterEnum("meshes.OpCategory", OpCategory_name, OpCategory_value)
@@ -600,8 +1222,12 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type MeshServiceClient interface {
 	CreateMeshInstance(ctx context.Context, in *CreateMeshInstanceRequest, opts ...grpc.CallOption) (*CreateMeshInstanceResponse, error)
+	ListMeshInstances(ctx context.Context, in *ListMeshInstancesRequest, opts ...grpc.CallOption) (*ListMeshInstancesResponse, error)
+	RemoveMeshInstance(ctx context.Context, in *RemoveMeshInstanceRequest, opts ...grpc.CallOption) (*RemoveMeshInstanceResponse, error)
 	MeshName(ctx context.Context, in *MeshNameRequest, opts ...grpc.CallOption) (*MeshNameResponse, error)
 	ApplyOperation(ctx context.Context, in *ApplyRuleRequest, opts ...grpc.CallOption) (*ApplyRuleResponse, error)
+	OperationStatus(ctx context.Context, in *OperationStatusRequest, opts ...grpc.CallOption) (*OperationStatusResponse, error)
+	CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationResponse, error)
 	SupportedOperations(ctx context.Context, in *SupportedOperationsRequest, opts ...grpc.CallOption) (*SupportedOperationsResponse, error)
 	StreamEvents(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (MeshService_StreamEventsClient, error)
 }
@@ -623,6 +1249,24 @@ func (c *meshServiceClient) CreateMeshInstance(ctx context.Context, in *CreateMe
 	return out, nil
 }
 
+func (c *meshServiceClient) ListMeshInstances(ctx context.Context, in *ListMeshInstancesRequest, opts ...grpc.CallOption) (*ListMeshInstancesResponse, error) {
+	out := new(ListMeshInstancesResponse)
+	err := c.cc.Invoke(ctx, "/meshes.MeshService/ListMeshInstances", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshServiceClient) RemoveMeshInstance(ctx context.Context, in *RemoveMeshInstanceRequest, opts ...grpc.CallOption) (*RemoveMeshInstanceResponse, error) {
+	out := new(RemoveMeshInstanceResponse)
+	err := c.cc.Invoke(ctx, "/meshes.MeshService/RemoveMeshInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meshServiceClient) MeshName(ctx context.Context, in *MeshNameRequest, opts ...grpc.CallOption) (*MeshNameResponse, error) {
 	out := new(MeshNameResponse)
 	err := c.cc.Invoke(ctx, "/meshes.MeshService/MeshName", in, out, opts...)
@@ -641,6 +1285,24 @@ func (c *meshServiceClient) ApplyOperation(ctx context.Context, in *ApplyRuleReq
 	return out, nil
 }
 
+func (c *meshServiceClient) OperationStatus(ctx context.Context, in *OperationStatusRequest, opts ...grpc.CallOption) (*OperationStatusResponse, error) {
+	out := new(OperationStatusResponse)
+	err := c.cc.Invoke(ctx, "/meshes.MeshService/OperationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshServiceClient) CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationResponse, error) {
+	out := new(CancelOperationResponse)
+	err := c.cc.Invoke(ctx, "/meshes.MeshService/CancelOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meshServiceClient) SupportedOperations(ctx context.Context, in *SupportedOperationsRequest, opts ...grpc.CallOption) (*SupportedOperationsResponse, error) {
 	out := new(SupportedOperationsResponse)
 	err := c.cc.Invoke(ctx, "/meshes.MeshService/SupportedOperations", in, out, opts...)
@@ -685,8 +1347,12 @@ func (x *meshServiceStreamEventsClient) Recv() (*EventsResponse, error) {
 // MeshServiceServer is the server API for MeshService service.
 type MeshServiceServer interface {
 	CreateMeshInstance(context.Context, *CreateMeshInstanceRequest) (*CreateMeshInstanceResponse, error)
+	ListMeshInstances(context.Context, *ListMeshInstancesRequest) (*ListMeshInstancesResponse, error)
+	RemoveMeshInstance(context.Context, *RemoveMeshInstanceRequest) (*RemoveMeshInstanceResponse, error)
 	MeshName(context.Context, *MeshNameRequest) (*MeshNameResponse, error)
 	ApplyOperation(context.Context, *ApplyRuleRequest) (*ApplyRuleResponse, error)
+	OperationStatus(context.Context, *OperationStatusRequest) (*OperationStatusResponse, error)
+	CancelOperation(context.Context, *CancelOperationRequest) (*CancelOperationResponse, error)
 	SupportedOperations(context.Context, *SupportedOperationsRequest) (*SupportedOperationsResponse, error)
 	StreamEvents(*EventsRequest, MeshService_StreamEventsServer) error
 }
@@ -713,6 +1379,42 @@ func _MeshService_CreateMeshInstance_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_ListMeshInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMeshInstancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).ListMeshInstances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshes.MeshService/ListMeshInstances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).ListMeshInstances(ctx, req.(*ListMeshInstancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshService_RemoveMeshInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMeshInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).RemoveMeshInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshes.MeshService/RemoveMeshInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).RemoveMeshInstance(ctx, req.(*RemoveMeshInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshService_MeshName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MeshNameRequest)
 	if err := dec(in); err != nil {
@@ -749,6 +1451,42 @@ func _MeshService_ApplyOperation_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshService_OperationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OperationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).OperationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshes.MeshService/OperationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).OperationStatus(ctx, req.(*OperationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshService_CancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshServiceServer).CancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meshes.MeshService/CancelOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshServiceServer).CancelOperation(ctx, req.(*CancelOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshService_SupportedOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SupportedOperationsRequest)
 	if err := dec(in); err != nil {
@@ -796,6 +1534,14 @@ var _MeshService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMeshInstance",
 			Handler:    _MeshService_CreateMeshInstance_Handler,
 		},
+		{
+			MethodName: "ListMeshInstances",
+			Handler:    _MeshService_ListMeshInstances_Handler,
+		},
+		{
+			MethodName: "RemoveMeshInstance",
+			Handler:    _MeshService_RemoveMeshInstance_Handler,
+		},
 		{
 			MethodName: "MeshName",
 			Handler:    _MeshService_MeshName_Handler,
@@ -804,6 +1550,14 @@ var _MeshService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplyOperation",
 			Handler:    _MeshService_ApplyOperation_Handler,
 		},
+		{
+			MethodName: "OperationStatus",
+			Handler:    _MeshService_OperationStatus_Handler,
+		},
+		{
+			MethodName: "CancelOperation",
+			Handler:    _MeshService_CancelOperation_Handler,
+		},
 		{
 			MethodName: "SupportedOperations",
 			Handler:    _MeshService_SupportedOperations_Handler,
@@ -819,51 +1573,84 @@ var _MeshService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "meshops.proto",
 }
 
-func init() { proto.RegisterFile("meshops.proto", fileDescriptor_meshops_70742207f69c0a34) }
-
-var fileDescriptor_meshops_70742207f69c0a34 = []byte{
-	// 676 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0x61, 0x6f, 0xda, 0x3c,
-	0x10, 0x6e, 0x80, 0x52, 0x38, 0x28, 0x4d, 0xfd, 0xbe, 0xeb, 0xd2, 0xb4, 0xd2, 0x68, 0x26, 0x4d,
-	0xa8, 0x9a, 0x50, 0xc5, 0xbe, 0xec, 0xdb, 0x94, 0x31, 0x5a, 0x45, 0xa2, 0xa4, 0x0a, 0x74, 0x93,
-	0x36, 0x4d, 0x2c, 0x85, 0x5b, 0x8b, 0x0a, 0xb1, 0x17, 0x9b, 0x6a, 0xf9, 0x29, 0xd3, 0x7e, 0xcf,
-	0xfe, 0xd7, 0xe4, 0x24, 0x0e, 0x5d, 0x43, 0xfb, 0xcd, 0xf7, 0xdc, 0xf9, 0xf1, 0x3d, 0xf6, 0x73,
-	0x86, 0xed, 0x05, 0xf2, 0x1b, 0xca, 0x78, 0x9b, 0x85, 0x54, 0x50, 0x52, 0x96, 0x21, 0x72, 0xeb,
-	0x0b, 0xec, 0x77, 0x43, 0xf4, 0x05, 0x9e, 0x23, 0xbf, 0x71, 0x02, 0x2e, 0xfc, 0x60, 0x82, 0x1e,
-	0xfe, 0x58, 0x22, 0x17, 0xe4, 0x10, 0xaa, 0xb7, 0x6f, 0x79, 0x97, 0x06, 0xdf, 0x67, 0xd7, 0x86,
-	0xd6, 0xd4, 0x5a, 0x75, 0x6f, 0x05, 0x90, 0x26, 0xd4, 0x26, 0x34, 0x10, 0xf8, 0x53, 0x0c, 0xfc,
-	0x05, 0x1a, 0x85, 0xa6, 0xd6, 0xaa, 0x7a, 0xf7, 0x21, 0xeb, 0x10, 0xcc, 0x75, 0xe4, 0x9c, 0xd1,
-	0x80, 0xa3, 0xb5, 0x0b, 0x3b, 0x12, 0x97, 0x95, 0xe9, 0x81, 0xd6, 0x2b, 0xd0, 0x57, 0x50, 0x52,
-	0x46, 0x08, 0x94, 0x02, 0xc9, 0xaf, 0xc5, 0xfc, 0xf1, 0xda, 0xfa, 0xa3, 0x81, 0x6e, 0x33, 0x36,
-	0x8f, 0xbc, 0xe5, 0x3c, 0xeb, 0x76, 0x0f, 0xca, 0x94, 0x0d, 0x56, 0xa5, 0x69, 0x24, 0x55, 0xc8,
-	0x4d, 0x9c, 0xf9, 0x13, 0xd5, 0xe5, 0x0a, 0x20, 0x26, 0x54, 0x96, 0x1c, 0xc3, 0xf8, 0x88, 0x62,
-	0x9c, 0xcc, 0x62, 0xf2, 0x02, 0x6a, 0x93, 0x25, 0x17, 0x74, 0x31, 0xbe, 0xa2, 0xd3, 0xc8, 0x28,
-	0xc5, 0x69, 0x48, 0xa0, 0xf7, 0x74, 0x1a, 0x91, 0x03, 0xa8, 0x4e, 0x71, 0x8e, 0x02, 0xc7, 0x94,
-	0x19, 0x9b, 0x4d, 0xad, 0x55, 0xf1, 0x2a, 0x09, 0xe0, 0x32, 0x72, 0x04, 0x75, 0xca, 0x30, 0xf4,
-	0xc5, 0x8c, 0x06, 0xe3, 0xd9, 0xd4, 0x28, 0x27, 0x17, 0x94, 0x61, 0xce, 0xd4, 0xea, 0xc3, 0xee,
-	0x3d, 0x19, 0xa9, 0xe0, 0xff, 0x61, 0x13, 0xc3, 0x90, 0x86, 0xa9, 0x8c, 0x24, 0xc8, 0xb1, 0x15,
-	0xf2, 0x6c, 0x87, 0x60, 0x0e, 0x97, 0x8c, 0xd1, 0x50, 0xe0, 0xd4, 0x55, 0x38, 0x57, 0x77, 0xeb,
-	0xc3, 0xc1, 0xda, 0x6c, 0x7a, 0xea, 0x6b, 0x28, 0x52, 0xc6, 0x0d, 0xad, 0x59, 0x6c, 0xd5, 0x3a,
-	0x66, 0x3b, 0xb1, 0x47, 0x3b, 0xbf, 0xc3, 0x93, 0x65, 0xab, 0x1e, 0x0b, 0xf7, 0x7a, 0xb4, 0xe6,
-	0x40, 0xf2, 0x1b, 0x88, 0x0e, 0xc5, 0x5b, 0x8c, 0x52, 0x35, 0x72, 0x29, 0x77, 0xdf, 0xf9, 0xf3,
-	0xa5, 0x7a, 0x8d, 0x24, 0x20, 0x6d, 0xa8, 0x4c, 0x7c, 0x81, 0xd7, 0x34, 0x8c, 0xe2, 0x97, 0x68,
-	0x74, 0x88, 0x6a, 0xc3, 0x65, 0xdd, 0x34, 0xe3, 0x65, 0x35, 0xd6, 0x0e, 0x6c, 0xf7, 0xee, 0x30,
-	0x10, 0x99, 0xc2, 0x5f, 0x1a, 0x34, 0x14, 0x92, 0xaa, 0x3a, 0x01, 0x40, 0x89, 0x8c, 0x45, 0xc4,
-	0x12, 0x5f, 0x34, 0x3a, 0xbb, 0x8a, 0x35, 0xae, 0x1d, 0x45, 0x0c, 0xbd, 0x2a, 0xaa, 0x25, 0x31,
-	0x60, 0x8b, 0x2f, 0x17, 0x0b, 0x3f, 0x8c, 0xd2, 0xee, 0x54, 0x28, 0x33, 0x53, 0x14, 0xfe, 0x6c,
-	0xce, 0x53, 0xa3, 0xa8, 0x30, 0xf7, 0x36, 0xa5, 0xdc, 0xdb, 0x1c, 0x7f, 0x06, 0x58, 0x89, 0x20,
-	0x35, 0xd8, 0x72, 0x06, 0xc3, 0x91, 0xdd, 0xef, 0xeb, 0x1b, 0x64, 0x0f, 0xc8, 0xd0, 0x3e, 0xbf,
-	0xe8, 0xf7, 0xc6, 0xf6, 0xc5, 0x45, 0xdf, 0xe9, 0xda, 0x23, 0xc7, 0x1d, 0xe8, 0x1a, 0xd9, 0x86,
-	0x6a, 0xd7, 0x1d, 0x9c, 0x3a, 0x67, 0x97, 0x5e, 0x4f, 0x2f, 0x90, 0x3a, 0x54, 0x3e, 0xda, 0x7d,
-	0xe7, 0x83, 0x3d, 0xea, 0xe9, 0x45, 0x02, 0x50, 0xee, 0x5e, 0x0e, 0x47, 0xee, 0xb9, 0x5e, 0x3a,
-	0x3e, 0x86, 0x6a, 0x26, 0x85, 0x54, 0xa0, 0xe4, 0x0c, 0x4e, 0x5d, 0x7d, 0x43, 0xae, 0x3e, 0xd9,
-	0x9e, 0x64, 0xaa, 0xc2, 0x66, 0xcf, 0xf3, 0x5c, 0x4f, 0x2f, 0x74, 0x7e, 0x17, 0xa1, 0x26, 0x47,
-	0x6c, 0x88, 0xe1, 0xdd, 0x6c, 0x82, 0xe4, 0x2b, 0x90, 0xfc, 0x88, 0x92, 0x23, 0x75, 0x45, 0x8f,
-	0xfe, 0x0d, 0xa6, 0xf5, 0x54, 0x49, 0x3a, 0xe1, 0x1b, 0xe4, 0x1d, 0x54, 0xd4, 0x40, 0x93, 0xe7,
-	0x6a, 0xc7, 0x83, 0xa9, 0x37, 0x8d, 0x7c, 0x22, 0x23, 0x38, 0x83, 0x46, 0x3c, 0x21, 0x2b, 0x3b,
-	0x65, 0xd5, 0x0f, 0x3f, 0x00, 0x73, 0x7f, 0x4d, 0x26, 0x23, 0xfa, 0x06, 0xff, 0xad, 0xb1, 0x3f,
-	0xb1, 0x1e, 0x77, 0xba, 0xf2, 0x95, 0xf9, 0xf2, 0xc9, 0x9a, 0xec, 0x04, 0x1b, 0xea, 0x43, 0x11,
-	0xa2, 0xbf, 0x48, 0x3c, 0x48, 0x9e, 0xfd, 0xe3, 0xb3, 0x8c, 0x6d, 0xef, 0x21, 0xac, 0x08, 0x4e,
-	0xb4, 0xab, 0x72, 0xfc, 0x39, 0xbf, 0xf9, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x27, 0xa3, 0x6e, 0x5a,
-	0xad, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("meshops.proto", fileDescriptor_meshops_f7dc18e50c5d38c0) }
+
+var fileDescriptor_meshops_f7dc18e50c5d38c0 = []byte{
+	// 1209 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x5b, 0x6f, 0xdb, 0xb6,
+	0x17, 0xaf, 0x7c, 0x8b, 0x75, 0xec, 0xb4, 0x0e, 0x9b, 0x7f, 0xa2, 0xa8, 0xc5, 0xbf, 0xae, 0x3a,
+	0x0c, 0x41, 0x30, 0x64, 0x45, 0xf6, 0xb0, 0x5e, 0x06, 0x0c, 0x9e, 0x9b, 0x16, 0x06, 0xd2, 0xd8,
+	0xa0, 0xdd, 0x0e, 0xe8, 0x50, 0x78, 0xaa, 0x45, 0xa7, 0x5a, 0x6d, 0x51, 0x25, 0xa9, 0x60, 0x7e,
+	0x1b, 0x86, 0x3d, 0xef, 0x69, 0x2f, 0xfb, 0x06, 0x7b, 0xdf, 0xa7, 0xd9, 0xb7, 0x19, 0x48, 0x89,
+	0x92, 0x6c, 0xd9, 0x59, 0xb6, 0x37, 0x9e, 0x2b, 0xcf, 0xe5, 0xc7, 0xa3, 0x23, 0xd8, 0x9e, 0x13,
+	0xfe, 0x9e, 0x86, 0xfc, 0x38, 0x64, 0x54, 0x50, 0x54, 0x93, 0x24, 0xe1, 0xce, 0x77, 0x70, 0xd0,
+	0x65, 0xc4, 0x15, 0xe4, 0x25, 0xe1, 0xef, 0x7b, 0x01, 0x17, 0x6e, 0x30, 0x21, 0x98, 0x7c, 0x8c,
+	0x08, 0x17, 0xe8, 0x2e, 0x98, 0x1f, 0x1e, 0xf1, 0x2e, 0x0d, 0xa6, 0xfe, 0x85, 0x65, 0xb4, 0x8d,
+	0xc3, 0x26, 0xce, 0x18, 0xa8, 0x0d, 0x8d, 0x09, 0x0d, 0x04, 0xf9, 0x51, 0x9c, 0xbb, 0x73, 0x62,
+	0x95, 0xda, 0xc6, 0xa1, 0x89, 0xf3, 0x2c, 0xe7, 0x2e, 0xd8, 0xeb, 0x9c, 0xf3, 0x90, 0x06, 0x9c,
+	0x38, 0x36, 0x58, 0x67, 0x3e, 0x17, 0x79, 0x19, 0x4f, 0x6e, 0x76, 0x1e, 0xc3, 0xc1, 0x1a, 0x59,
+	0x6c, 0x28, 0xc3, 0xf2, 0x35, 0xd3, 0x32, 0xda, 0xe5, 0x43, 0x13, 0x67, 0x0c, 0xe7, 0x4b, 0x38,
+	0xc0, 0x64, 0x4e, 0x2f, 0xd7, 0x66, 0x64, 0x43, 0x5d, 0x6b, 0xaa, 0x84, 0x4c, 0x9c, 0xd2, 0x32,
+	0xda, 0x75, 0x86, 0x49, 0xb4, 0x3b, 0x70, 0x4b, 0xf2, 0x65, 0x5e, 0x3a, 0xc8, 0x4f, 0xa1, 0x95,
+	0xb1, 0x92, 0xd8, 0x10, 0x54, 0x02, 0x77, 0xae, 0x9d, 0xab, 0xb3, 0xf3, 0x5b, 0x19, 0x5a, 0x9d,
+	0x30, 0x9c, 0x2d, 0x70, 0x34, 0x4b, 0x23, 0xd9, 0x83, 0x1a, 0x0d, 0xcf, 0x33, 0xd5, 0x84, 0x92,
+	0xc9, 0x49, 0x23, 0x1e, 0xba, 0x13, 0x5d, 0xd3, 0x8c, 0x21, 0xe3, 0x8f, 0x38, 0x61, 0xea, 0x8a,
+	0x72, 0x1c, 0xbf, 0xa6, 0xd1, 0x3d, 0x68, 0x4c, 0x22, 0x2e, 0xe8, 0x7c, 0xfc, 0x8e, 0x7a, 0x0b,
+	0xab, 0xa2, 0xc4, 0x10, 0xb3, 0xbe, 0xa1, 0xde, 0x02, 0xdd, 0x01, 0xd3, 0x23, 0x33, 0x22, 0xc8,
+	0x98, 0x86, 0x56, 0xb5, 0x6d, 0x1c, 0xd6, 0x71, 0x3d, 0x66, 0xf4, 0x43, 0x74, 0x1f, 0x9a, 0x34,
+	0x24, 0xcc, 0x15, 0x3e, 0x0d, 0xc6, 0xbe, 0x67, 0xd5, 0xe2, 0x76, 0xa6, 0xbc, 0x9e, 0x87, 0xf6,
+	0x61, 0xcb, 0x63, 0x8b, 0x31, 0x8b, 0x02, 0x6b, 0x4b, 0x59, 0xd7, 0x3c, 0xb6, 0xc0, 0x51, 0xb0,
+	0x54, 0xd5, 0xfa, 0x72, 0x55, 0x65, 0x54, 0x32, 0xc2, 0xf1, 0x05, 0xa3, 0x51, 0xc8, 0x2d, 0x53,
+	0xb5, 0x0b, 0x24, 0xeb, 0x85, 0xe2, 0xa0, 0xaf, 0xa0, 0x16, 0xba, 0xcc, 0x9d, 0x73, 0x0b, 0xda,
+	0xe5, 0xc3, 0xc6, 0xc9, 0x27, 0xc7, 0x31, 0x34, 0x8f, 0x57, 0x4b, 0x76, 0x3c, 0x50, 0x6a, 0xa7,
+	0x81, 0x60, 0x0b, 0x9c, 0xd8, 0xd8, 0x8f, 0xa1, 0x91, 0x63, 0xa3, 0x16, 0x94, 0x3f, 0x90, 0x45,
+	0x52, 0x52, 0x79, 0x44, 0xbb, 0x50, 0xbd, 0x74, 0x67, 0x91, 0xae, 0x65, 0x4c, 0x3c, 0x29, 0x3d,
+	0x32, 0x9c, 0xbf, 0x0c, 0xd8, 0xc9, 0xdd, 0x91, 0x34, 0x70, 0x17, 0xaa, 0x84, 0x31, 0xca, 0x12,
+	0x1f, 0x31, 0x51, 0xa8, 0x4e, 0xa9, 0x58, 0x9d, 0x23, 0xa8, 0x7a, 0xfe, 0x74, 0xca, 0xad, 0xb2,
+	0x4a, 0x63, 0x57, 0xa7, 0x81, 0x09, 0xa7, 0x11, 0x9b, 0x90, 0x67, 0xfe, 0x74, 0x8a, 0x63, 0x15,
+	0xf4, 0x39, 0xdc, 0x9e, 0xfb, 0x9c, 0xfb, 0xc1, 0xc5, 0x38, 0x24, 0x4c, 0x1d, 0x69, 0xc0, 0xad,
+	0x8a, 0x2a, 0x0e, 0x4a, 0x44, 0x83, 0x4c, 0x82, 0x8e, 0x61, 0x8b, 0xbe, 0xfb, 0x81, 0x4c, 0x04,
+	0xb7, 0xaa, 0xcb, 0xee, 0xfb, 0x8a, 0x8d, 0x09, 0x8f, 0x66, 0x02, 0x6b, 0x25, 0xe7, 0x27, 0x03,
+	0x9a, 0xf9, 0x8b, 0x25, 0xdc, 0xdc, 0x89, 0x8c, 0x54, 0xc3, 0x2d, 0xa6, 0x24, 0x5e, 0x3f, 0xf8,
+	0x81, 0x4e, 0x48, 0x9d, 0x97, 0x21, 0x58, 0x5e, 0x85, 0xa0, 0x46, 0x78, 0x25, 0x43, 0xb8, 0xe4,
+	0xc9, 0xc4, 0x14, 0xa8, 0x4c, 0xac, 0xce, 0xce, 0x2f, 0x06, 0x34, 0xf3, 0xc1, 0xa5, 0x57, 0x19,
+	0x9b, 0xae, 0x2a, 0x6d, 0xba, 0xaa, 0x9c, 0xbb, 0xca, 0x82, 0x2d, 0x1a, 0x89, 0x09, 0x4d, 0x23,
+	0xd0, 0x64, 0xd6, 0xb9, 0x6a, 0xae, 0x73, 0xce, 0x53, 0xd8, 0xeb, 0xeb, 0x2e, 0x0d, 0x85, 0x2b,
+	0x22, 0x3d, 0x63, 0x0a, 0x3d, 0x35, 0x0a, 0x3d, 0x75, 0x7e, 0x2d, 0xc1, 0x7e, 0xc1, 0x3a, 0x01,
+	0xca, 0x3f, 0x9b, 0xcb, 0x07, 0x43, 0xc3, 0x71, 0x90, 0x4d, 0x47, 0xfd, 0xc8, 0x77, 0xa1, 0xca,
+	0x85, 0x2b, 0x74, 0x66, 0x31, 0x91, 0x25, 0x50, 0xc9, 0x43, 0x2f, 0xc5, 0x55, 0xf5, 0x3f, 0xe3,
+	0xaa, 0x76, 0x1d, 0x5c, 0x6d, 0x5d, 0x07, 0x57, 0x4f, 0x61, 0xaf, 0x2b, 0x9f, 0xf5, 0x2c, 0xad,
+	0xca, 0xbf, 0xa8, 0xe6, 0x01, 0xec, 0x17, 0x8c, 0x93, 0xe9, 0x7a, 0x17, 0xec, 0x61, 0x14, 0x86,
+	0x94, 0x09, 0xe2, 0xa5, 0xd2, 0xf4, 0x6b, 0xe0, 0xc2, 0x9d, 0xb5, 0xd2, 0xa4, 0x13, 0x9f, 0x41,
+	0x99, 0x86, 0xf1, 0x97, 0xa0, 0x71, 0x62, 0xeb, 0x04, 0x8a, 0x16, 0x58, 0xaa, 0x65, 0x55, 0x2e,
+	0xe5, 0x61, 0xf2, 0x87, 0x01, 0xa8, 0x68, 0x71, 0xdd, 0x79, 0x82, 0x8e, 0xa1, 0x3e, 0x71, 0x05,
+	0xb9, 0xa0, 0x6c, 0xa1, 0x7a, 0x7a, 0xf3, 0x04, 0xa5, 0x85, 0x0c, 0xbb, 0x89, 0x04, 0xa7, 0x3a,
+	0xe8, 0x09, 0x80, 0x1a, 0x60, 0x44, 0x10, 0x16, 0xbf, 0xfb, 0x5c, 0xe4, 0xe9, 0xf5, 0x03, 0xad,
+	0x82, 0x73, 0xda, 0xce, 0x9f, 0x06, 0xa0, 0xa2, 0xca, 0xba, 0x2f, 0x8f, 0xe4, 0x89, 0x45, 0xa8,
+	0x63, 0x55, 0x67, 0xf4, 0x00, 0xb6, 0x3d, 0x32, 0x75, 0xa3, 0x99, 0x18, 0xc7, 0x89, 0xc4, 0x18,
+	0x6c, 0x26, 0xcc, 0xd7, 0x2a, 0x1f, 0x1b, 0xea, 0x8c, 0x7c, 0x8c, 0x7c, 0x46, 0x3c, 0x85, 0xc6,
+	0x3a, 0x4e, 0x69, 0xe9, 0x94, 0x04, 0xd1, 0x5c, 0xe1, 0xd1, 0xc4, 0xea, 0x2c, 0x77, 0x01, 0x8f,
+	0xf0, 0x09, 0xf3, 0x43, 0x35, 0x63, 0x92, 0x8f, 0x47, 0x8e, 0xe5, 0xdc, 0x82, 0xed, 0xd3, 0x4b,
+	0x12, 0x88, 0xb4, 0xa9, 0xbf, 0x1b, 0x70, 0x53, 0x73, 0x92, 0x46, 0x3e, 0x04, 0x20, 0x92, 0x33,
+	0x56, 0x41, 0x1b, 0xaa, 0x8e, 0x3b, 0xba, 0x2a, 0x4a, 0x77, 0xb4, 0x08, 0x09, 0x36, 0x89, 0x3e,
+	0xca, 0x69, 0xc0, 0xa3, 0xf9, 0xdc, 0x65, 0x8b, 0x24, 0x47, 0x4d, 0x4a, 0x89, 0x47, 0x84, 0xeb,
+	0xcf, 0x78, 0x92, 0xa0, 0x26, 0x0b, 0x48, 0xad, 0x14, 0x90, 0x7a, 0xf4, 0x06, 0x20, 0x6b, 0x1b,
+	0x6a, 0xc0, 0x56, 0xef, 0x7c, 0x38, 0xea, 0x9c, 0x9d, 0xb5, 0x6e, 0xa0, 0x3d, 0x40, 0xc3, 0xce,
+	0xcb, 0xc1, 0xd9, 0xe9, 0xb8, 0x33, 0x18, 0x9c, 0xf5, 0xba, 0x9d, 0x51, 0xaf, 0x7f, 0xde, 0x32,
+	0xd0, 0x36, 0x98, 0xdd, 0xfe, 0xf9, 0xf3, 0xde, 0x8b, 0x57, 0xf8, 0xb4, 0x55, 0x42, 0x4d, 0xa8,
+	0xbf, 0xee, 0x9c, 0xf5, 0x9e, 0x75, 0x46, 0xa7, 0xad, 0x32, 0x02, 0xa8, 0x75, 0x5f, 0x0d, 0x47,
+	0xfd, 0x97, 0xad, 0xca, 0xd1, 0x11, 0x98, 0x69, 0x2a, 0xa8, 0x0e, 0x95, 0xde, 0xf9, 0xf3, 0x7e,
+	0xeb, 0x86, 0x3c, 0x7d, 0xdb, 0xc1, 0xd2, 0x93, 0x09, 0xd5, 0x53, 0x8c, 0xfb, 0xb8, 0x55, 0x3a,
+	0xf9, 0xb9, 0x06, 0x0d, 0xb9, 0x62, 0x0c, 0x09, 0xbb, 0xf4, 0x27, 0x04, 0xbd, 0x05, 0x54, 0x5c,
+	0xa8, 0xd0, 0x7d, 0x5d, 0xa2, 0x8d, 0x9b, 0x9c, 0xed, 0x5c, 0xa5, 0x92, 0xbc, 0xc1, 0x1b, 0xe8,
+	0x0d, 0xec, 0x14, 0xb6, 0x2e, 0xd4, 0xd6, 0xa6, 0x9b, 0x96, 0x35, 0xfb, 0xfe, 0x15, 0x1a, 0xa9,
+	0xef, 0xb7, 0x80, 0x8a, 0xdb, 0x55, 0x16, 0xfa, 0xc6, 0x95, 0xcd, 0x76, 0xae, 0x52, 0x49, 0xdd,
+	0x7f, 0x0d, 0x75, 0xbd, 0x8b, 0xa1, 0x7d, 0x6d, 0xb1, 0xb2, 0xb0, 0xd9, 0x56, 0x51, 0x90, 0x3a,
+	0x78, 0x01, 0x37, 0xd5, 0x32, 0x90, 0xbd, 0x7d, 0x6b, 0xd3, 0x22, 0x62, 0x1f, 0xac, 0x91, 0xa4,
+	0x8e, 0x46, 0x70, 0x6b, 0xe5, 0x93, 0x81, 0xfe, 0x5f, 0x78, 0xd9, 0x4b, 0x5f, 0x22, 0xfb, 0xde,
+	0x46, 0x79, 0xde, 0xeb, 0xca, 0xec, 0xcc, 0xbc, 0xae, 0x9f, 0xc8, 0xf6, 0xbd, 0x8d, 0xf2, 0xd4,
+	0xeb, 0xf7, 0x70, 0x7b, 0xcd, 0x60, 0x45, 0xce, 0xe6, 0x19, 0x9a, 0xc6, 0xfc, 0xe0, 0x4a, 0x9d,
+	0xf4, 0x86, 0x0e, 0x34, 0x87, 0x82, 0x11, 0x77, 0x1e, 0x3f, 0x75, 0xf4, 0xbf, 0xa5, 0xe7, 0x9c,
+	0x7a, 0xdb, 0x5b, 0x65, 0x6b, 0x07, 0x0f, 0x8d, 0x77, 0x35, 0xf5, 0xc7, 0xf2, 0xc5, 0xdf, 0x03,
+	0x00, 0x65, 0x93, 0x51, 0x79, 0xc2, 0x0c, 0x00, 0x00,
 }